@@ -0,0 +1,50 @@
+// =============================================================================
+// Project: tinyfmt
+// File: append.go
+// Description: Functions for formatting into caller-owned byte slices.
+// Datasheet/Docs:
+//
+// Author: Jason Duffy
+// Created on: 18/10/2026
+//
+// Copyright: (C) 2026, Jason Duffy
+// License: See LICENSE file in the project root for full license information.
+// Disclaimer: See DISCLAIMER file in the project root for full disclaimer.
+// =============================================================================
+
+// -------------------------------------------------------------------------- //
+//                               Import Statement                             //
+// -------------------------------------------------------------------------- //
+
+package tinyfmt
+
+// -------------------------------------------------------------------------- //
+//                              Public Functions                              //
+// -------------------------------------------------------------------------- //
+
+// Append formats the arguments like Sprint, appends the result to dst and
+// returns the extended slice. Nothing is allocated if dst has room.
+func Append(dst []byte, arguments ...interface{}) []byte {
+	p := printer{buf: dst}
+	p.print(arguments)
+	return p.buf
+}
+
+// Appendf formats according to a format specifier like Sprintf, appends the
+// result to dst and returns the extended slice. On a formatting error dst is
+// returned at its original length along with the *FormatError.
+func Appendf(dst []byte, format string, arguments ...interface{}) ([]byte, error) {
+	p := printer{buf: dst}
+	if err := p.printf(format, arguments); err != nil {
+		return dst, err
+	}
+	return p.buf, nil
+}
+
+// Appendln formats the arguments like Sprintln, appends the result to dst and
+// returns the extended slice.
+func Appendln(dst []byte, arguments ...interface{}) []byte {
+	p := printer{buf: dst}
+	p.println(arguments)
+	return p.buf
+}
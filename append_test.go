@@ -0,0 +1,77 @@
+// =============================================================================
+// Project: tinyfmt
+// File: append_test.go
+// Description: Test suite for append functions in tinyfmt package.
+// Datasheet/Docs:
+//
+// Author: Jason Duffy
+// Created on: 18/10/2026
+//
+// Copyright: (C) 2026, Jason Duffy
+// License: See LICENSE file in the project root for full license information.
+// Disclaimer: See DISCLAIMER file in the project root for full disclaimer.
+// =============================================================================
+
+// -------------------------------------------------------------------------- //
+//                               Import Statement                             //
+// -------------------------------------------------------------------------- //
+
+package tinyfmt
+
+import (
+	"errors"
+	"testing"
+)
+
+// -------------------------------------------------------------------------- //
+//                              Public Functions                              //
+// -------------------------------------------------------------------------- //
+
+func TestAppend(t *testing.T) {
+	testCases := []struct {
+		name   string
+		append func(dst []byte) []byte
+		want   string
+	}{
+		{"Append", func(dst []byte) []byte { return Append(dst, "t=", 21, 5) }, "log: t=21 5"},
+		{"Appendln", func(dst []byte) []byte { return Appendln(dst, "t=", 21) }, "log: t= 21\n"},
+		{"Appendf", func(dst []byte) []byte {
+			result, err := Appendf(dst, "t=%.1f%%", 21.25)
+			if err != nil {
+				t.Errorf("Appendf unexpected error: %v", err)
+			}
+			return result
+		}, "log: t=21.2%"},
+	}
+
+	for _, testCase := range testCases {
+		// Test appending into spare capacity reuses the caller's array
+		buf := make([]byte, 0, 64)
+		buf = append(buf, "log: "...)
+		got := testCase.append(buf)
+		if string(got) != testCase.want {
+			t.Errorf("%s = %q, want %q", testCase.name, got, testCase.want)
+		}
+		if &got[0] != &buf[0] {
+			t.Errorf("%s reallocated a buffer with spare capacity", testCase.name)
+		}
+
+		// Test appending to a nil slice
+		if got := testCase.append(nil); string(got) != testCase.want[len("log: "):] {
+			t.Errorf("%s(nil) = %q, want %q", testCase.name, got, testCase.want[len("log: "):])
+		}
+	}
+}
+
+func TestAppendfError(t *testing.T) {
+	buf := []byte("log: ")
+	got, err := Appendf(buf, "t=%d", "hot")
+
+	var formatErr *FormatError
+	if !errors.As(err, &formatErr) || formatErr.Kind != WrongArgumentType {
+		t.Errorf("Appendf error = %v, want a WrongArgumentType *FormatError", err)
+	}
+	if string(got) != "log: " {
+		t.Errorf("Appendf after an error = %q, want the original %q", got, "log: ")
+	}
+}
@@ -0,0 +1,558 @@
+// =============================================================================
+// Project: tinyfmt
+// File: main.go
+// Description: Generates reflection-free Format methods for struct types.
+// Datasheet/Docs: https://go.dev/blog/generate
+//
+// Author: Jason Duffy
+// Created on: 18/10/2026
+//
+// Copyright: (C) 2026, Jason Duffy
+// License: See LICENSE file in the project root for full license information.
+// Disclaimer: See DISCLAIMER file in the project root for full disclaimer.
+// =============================================================================
+
+// Command tinyfmt-gen writes tinyfmt.Formatter methods for struct types, so
+// they print like tinyfmt's %v, %+v and %#v without reflection. It is meant to be
+// run by go generate from the package that declares the types:
+//
+//	//go:generate go run github.com/Jason-Duffy/tinyfmt/cmd/tinyfmt-gen -type=Reading,Config
+//
+// The methods are written to <first type>_tinyfmt.go in the package
+// directory, or to the file named by -output.
+package main
+
+// -------------------------------------------------------------------------- //
+//                               Import Statement                             //
+// -------------------------------------------------------------------------- //
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Jason-Duffy/tinyfmt"
+)
+
+// -------------------------------------------------------------------------- //
+//                             Type Definitions                               //
+// -------------------------------------------------------------------------- //
+
+// structType is a struct type to generate a Format method for.
+type structType struct {
+	name   string        // The type's name
+	fields []structField // The fields, in declaration order
+}
+
+// structField is a field of a struct type. Pointer and interface fields are
+// printed by the generated code itself, as fmt prints them inside a struct.
+type structField struct {
+	name        string   // The field's name, which is its type's for an embedded field
+	goType      string   // The field's type as %#v writes it, if it is a pointer or interface
+	pointer     bool     // Whether the field is a pointer, which prints as an address
+	isInterface bool     // Whether the field is an interface, which prints as T(nil) when nil
+	underlying  string   // The type an unexported field is converted to, so its methods are not called
+	zero        string   // The zero value that a blank field, which cannot be read, prints as
+	imports     []string // Packages that the underlying type names
+}
+
+// -------------------------------------------------------------------------- //
+//                               Main Function                                //
+// -------------------------------------------------------------------------- //
+
+func main() {
+	typeNames := flag.String("type", "", "comma-separated list of struct type names; required")
+	output := flag.String("output", "", "output file name; default <dir>/<type>_tinyfmt.go")
+	flag.Parse()
+
+	if *typeNames == "" {
+		fail(errors.New("tinyfmt-gen: -type is required"))
+	}
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+	names := strings.Split(*typeNames, ",")
+
+	src, err := generate(dir, names)
+	if err != nil {
+		fail(err)
+	}
+
+	if *output == "" {
+		*output = filepath.Join(dir, strings.ToLower(names[0])+"_tinyfmt.go")
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		fail(err)
+	}
+}
+
+// -------------------------------------------------------------------------- //
+//                          Private Helper Functions                          //
+// -------------------------------------------------------------------------- //
+
+// generate parses the package in dir and returns the formatted source of a
+// file with a Format method for each named struct type.
+func generate(dir string, names []string) ([]byte, error) {
+	packageName, structs, err := parsePackage(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var methods bytes.Buffer
+	imports := map[string]bool{"io": true}
+	for _, name := range names {
+		st, ok := structs[name]
+		if !ok {
+			return nil, errors.New("tinyfmt-gen: no struct type " + name + " in " + dir)
+		}
+		for _, field := range st.fields {
+			if field.pointer {
+				imports["unsafe"] = true
+			}
+			for _, path := range field.imports {
+				imports[path] = true
+			}
+		}
+		writeFormatMethod(&methods, packageName, st)
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by tinyfmt-gen; DO NOT EDIT.\n\n")
+	out.WriteString("package " + packageName + "\n\n")
+	paths := make([]string, 0, len(imports))
+	for path := range imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	out.WriteString("import (\n")
+	for _, path := range paths {
+		out.WriteString("\t" + quote(path) + "\n")
+	}
+	out.WriteString("\n\t\"github.com/Jason-Duffy/tinyfmt\"\n)\n")
+	out.Write(methods.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, errors.New("tinyfmt-gen: generated bad source: " + err.Error())
+	}
+	return src, nil
+}
+
+// parsePackage parses and type-checks the non-test Go files in dir,
+// returning the package name and the struct types declared at the top level.
+// Type errors, such as imports that cannot be found, are ignored; the types
+// are only needed to look through the named types of unexported fields.
+func parsePackage(dir string) (string, map[string]structType, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return "", nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") || strings.HasSuffix(path, "_tinyfmt.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return "", nil, err
+		}
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return "", nil, errors.New("tinyfmt-gen: no Go files in " + dir)
+	}
+	packageName := files[0].Name.Name
+
+	config := types.Config{Importer: importer.Default(), Error: func(error) {}}
+	pkg, _ := config.Check(packageName, fset, files, nil)
+
+	structs := make(map[string]structType)
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				structNode, ok := typeSpec.Type.(*ast.StructType)
+				if !ok || typeSpec.TypeParams != nil {
+					continue // Only non-generic struct types are supported
+				}
+				structs[typeSpec.Name.Name] = structType{
+					name:   typeSpec.Name.Name,
+					fields: structFields(packageName, structNode, checkedStruct(pkg, typeSpec.Name.Name)),
+				}
+			}
+		}
+	}
+	return packageName, structs, nil
+}
+
+// checkedStruct returns the type-checked struct type with the given name, or
+// nil if type checking could not resolve it.
+func checkedStruct(pkg *types.Package, name string) *types.Struct {
+	if pkg == nil {
+		return nil
+	}
+	object, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil
+	}
+	checked, _ := object.Type().Underlying().(*types.Struct)
+	return checked
+}
+
+// structFields lists a struct's fields. An embedded field is named after its
+// type. A blank field cannot be read, so it prints as the zero value of its
+// type, which is what it holds unless it was written through unsafe. checked
+// is the type-checked struct, if there is one, which gives the fields' types;
+// the syntax is only used when type checking failed.
+func structFields(packageName string, structNode *ast.StructType, checked *types.Struct) []structField {
+	var fields []structField
+	index := 0
+	for _, node := range structNode.Fields.List {
+		var names []string
+		if len(node.Names) == 0 {
+			names = append(names, embeddedName(node.Type))
+		}
+		for _, name := range node.Names {
+			names = append(names, name.Name)
+		}
+
+		for _, name := range names {
+			var variable *types.Var
+			if checked != nil && index < checked.NumFields() {
+				variable = checked.Field(index)
+			}
+			index++
+
+			field := structField{name: name, goType: goTypeName(packageName, node.Type)}
+			switch fieldType := node.Type.(type) {
+			case *ast.StarExpr:
+				field.pointer = true
+			case *ast.InterfaceType:
+				field.isInterface = true
+			case *ast.Ident:
+				field.isInterface = fieldType.Name == "error" || fieldType.Name == "any"
+			}
+
+			if variable != nil && variable.Type() == types.Typ[types.Invalid] {
+				variable = nil
+			}
+			if variable != nil {
+				field.goType = reflectTypeName(variable.Type())
+				field.isInterface = types.IsInterface(variable.Type())
+			}
+			if name == "_" {
+				field.zero = "*new(" + types.ExprString(node.Type) + ")"
+				if variable != nil {
+					field.zero = "*new(" + types.TypeString(variable.Type(), field.qualifier(variable.Pkg())) + ")"
+				}
+			}
+			fields = append(fields, withUnderlying(field, variable))
+		}
+	}
+	return fields
+}
+
+// withUnderlying sets the type an unexported field is converted to before it
+// is formatted. Reflection cannot call the methods of an unexported field, so
+// one of a named type prints as its underlying type. The conversion is only
+// made where it can be written: for basic types, and for the package's own
+// types, whose underlying types name nothing unexported from elsewhere.
+// Pointers and interfaces are left alone, as converting them would not hide
+// their methods.
+func withUnderlying(field structField, variable *types.Var) structField {
+	if variable == nil || token.IsExported(field.name) || field.pointer {
+		return field
+	}
+	named, ok := variable.Type().(*types.Named)
+	if !ok {
+		return field
+	}
+
+	own := variable.Pkg()
+	switch underlying := named.Underlying().(type) {
+	case *types.Basic:
+		if underlying.Kind() != types.Invalid {
+			field.underlying = underlying.Name()
+		}
+	case *types.Interface, *types.Pointer:
+	default:
+		if named.Obj().Pkg() == own {
+			field.underlying = "(" + types.TypeString(underlying, field.qualifier(own)) + ")"
+		}
+	}
+	return field
+}
+
+// qualifier returns a types.Qualifier for writing types in the generated
+// file, which is in the package own. Other packages are named as they call
+// themselves and recorded as imports of the field.
+func (field *structField) qualifier(own *types.Package) types.Qualifier {
+	return func(other *types.Package) string {
+		if other == own {
+			return ""
+		}
+		field.imports = append(field.imports, other.Path())
+		return other.Name()
+	}
+}
+
+// embeddedName returns the field name of an embedded type such as *pkg.T.
+func embeddedName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(expr.X)
+	case *ast.SelectorExpr:
+		return expr.Sel.Name
+	case *ast.IndexExpr:
+		return embeddedName(expr.X)
+	case *ast.IndexListExpr:
+		return embeddedName(expr.X)
+	default:
+		return expr.(*ast.Ident).Name
+	}
+}
+
+// reflectTypeName returns a checked type as reflection names it: every named
+// type, the package's own included, is qualified with its package's name,
+// and literal types are spelled as reflect.Type.String spells them.
+func reflectTypeName(t types.Type) string {
+	switch t := t.(type) {
+	case *types.Basic:
+		return types.Typ[t.Kind()].Name() // byte and rune are uint8 and int32
+	case *types.Pointer:
+		return "*" + reflectTypeName(t.Elem())
+	case *types.Slice:
+		return "[]" + reflectTypeName(t.Elem())
+	case *types.Array:
+		return "[" + strconv.FormatInt(t.Len(), 10) + "]" + reflectTypeName(t.Elem())
+	case *types.Map:
+		return "map[" + reflectTypeName(t.Key()) + "]" + reflectTypeName(t.Elem())
+	case *types.Chan:
+		switch t.Dir() {
+		case types.SendOnly:
+			return "chan<- " + reflectTypeName(t.Elem())
+		case types.RecvOnly:
+			return "<-chan " + reflectTypeName(t.Elem())
+		}
+		return "chan " + reflectTypeName(t.Elem())
+	case *types.Signature:
+		return "func" + reflectTupleName(t.Params(), t.Variadic()) + reflectResultName(t.Results())
+	case *types.Struct:
+		if t.NumFields() == 0 {
+			return "struct {}"
+		}
+		name := "struct {"
+		for i := 0; i < t.NumFields(); i++ {
+			if i > 0 {
+				name += ";"
+			}
+			name += " "
+			if !t.Field(i).Embedded() {
+				name += t.Field(i).Name() + " "
+			}
+			name += reflectTypeName(t.Field(i).Type())
+			if tag := t.Tag(i); tag != "" {
+				name += " " + strconv.Quote(tag)
+			}
+		}
+		return name + " }"
+	case *types.Named:
+		return types.TypeString(t, func(p *types.Package) string { return p.Name() })
+	}
+	if iface, ok := t.Underlying().(*types.Interface); ok && iface.Empty() {
+		return "interface {}" // Also any, which is an alias
+	}
+	return types.TypeString(t, func(p *types.Package) string { return p.Name() })
+}
+
+// reflectTupleName returns a function's parameters as reflection names
+// them, in parentheses and without their names.
+func reflectTupleName(tuple *types.Tuple, variadic bool) string {
+	name := ""
+	for i := 0; i < tuple.Len(); i++ {
+		if i > 0 {
+			name += ", "
+		}
+		if variadic && i == tuple.Len()-1 {
+			name += "..." + reflectTypeName(tuple.At(i).Type().(*types.Slice).Elem())
+		} else {
+			name += reflectTypeName(tuple.At(i).Type())
+		}
+	}
+	return "(" + name + ")"
+}
+
+// reflectResultName returns a function's results as reflection names them:
+// nothing for none, a bare type for one and a parenthesised list otherwise.
+func reflectResultName(results *types.Tuple) string {
+	switch results.Len() {
+	case 0:
+		return ""
+	case 1:
+		return " " + reflectTypeName(results.At(0).Type())
+	default:
+		return " " + reflectTupleName(results, false)
+	}
+}
+
+// goTypeName returns a type as reflection names it, qualifying the
+// package's own types with its name. It is only used when type checking
+// failed, and only needs to be right for the pointer and interface fields
+// that the generated code prints itself.
+func goTypeName(packageName string, expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		if expr.Name == "any" {
+			return "interface {}"
+		}
+		if isPredeclared(expr.Name) {
+			return expr.Name
+		}
+		return packageName + "." + expr.Name
+	case *ast.SelectorExpr:
+		if pkg, ok := expr.X.(*ast.Ident); ok {
+			return pkg.Name + "." + expr.Sel.Name
+		}
+		return "?"
+	case *ast.StarExpr:
+		return "*" + goTypeName(packageName, expr.X)
+	case *ast.ArrayType:
+		if expr.Len == nil {
+			return "[]" + goTypeName(packageName, expr.Elt)
+		}
+		length, _ := expr.Len.(*ast.BasicLit)
+		if length == nil {
+			return "[?]" + goTypeName(packageName, expr.Elt)
+		}
+		return "[" + length.Value + "]" + goTypeName(packageName, expr.Elt)
+	case *ast.MapType:
+		return "map[" + goTypeName(packageName, expr.Key) + "]" + goTypeName(packageName, expr.Value)
+	case *ast.InterfaceType:
+		return "interface {}"
+	default:
+		return "?"
+	}
+}
+
+// isPredeclared reports whether name is one of Go's predeclared types.
+func isPredeclared(name string) bool {
+	switch name {
+	case "bool", "string", "error", "byte", "rune", "uintptr",
+		"int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64",
+		"float32", "float64", "complex64", "complex128":
+		return true
+	default:
+		return false
+	}
+}
+
+// writeFormatMethod writes a Format method that prints the struct as %v,
+// %+v and %#v do, with each field formatted by tinyfmt.Append or Appendf.
+func writeFormatMethod(out *bytes.Buffer, packageName string, st structType) {
+	out.WriteString("\n// Format implements tinyfmt.Formatter, printing " + st.name + " as %v, %+v and\n")
+	out.WriteString("// %#v do without reflection.\n")
+	out.WriteString("func (x " + st.name + ") Format(state tinyfmt.State, verb rune) {\n")
+	out.WriteString("var storage [64]byte\n")
+	out.WriteString("dump := storage[:0]\n")
+	out.WriteString("switch {\n")
+	out.WriteString("case verb == 'v' && state.Flag('#'):\n")
+	writeFields(out, st, packageName+"."+st.name+"{", ", ", true, "%#v")
+	out.WriteString("case verb == 'v' && state.Flag('+'):\n")
+	writeFields(out, st, "{", " ", true, "%+v")
+	out.WriteString("default:\n")
+	writeFields(out, st, "{", " ", false, "%v")
+	out.WriteString("}\n\n")
+	out.WriteString("if verb != 'v' && verb != 's' {\n")
+	out.WriteString("io.WriteString(state, \"%!\"+string(verb)+" + quote("("+packageName+"."+st.name+"=") + "+string(dump)+\")\")\n")
+	out.WriteString("return\n")
+	out.WriteString("}\n")
+	out.WriteString("width, _ := state.Width()\n")
+	out.WriteString("if state.Flag('-') {\n")
+	out.WriteString("width = -width\n")
+	out.WriteString("}\n")
+	out.WriteString("tinyfmt.Fprintf(state, \"%*s\", width, string(dump))\n")
+	out.WriteString("}\n")
+}
+
+// writeFields writes the statements that append the struct's fields to dump
+// for one of %v, %+v or %#v, after the opening text and with the separator
+// between fields.
+func writeFields(out *bytes.Buffer, st structType, open, separator string, named bool, verb string) {
+	goSyntax := verb == "%#v"
+	text := open
+	for i, field := range st.fields {
+		if i > 0 {
+			text += separator
+		}
+		if named {
+			text += field.name + ":"
+		}
+		out.WriteString("dump = append(dump, " + quote(text) + "...)\n")
+		text = ""
+
+		value := "x." + field.name
+		if field.zero != "" {
+			value = field.zero
+		}
+		if field.underlying != "" {
+			value = field.underlying + "(" + value + ")"
+		}
+		switch {
+		case field.pointer:
+			nilText, address := "<nil>", "%p"
+			if goSyntax {
+				nilText, address = "("+field.goType+")(nil)", "("+field.goType+")(%p)"
+			}
+			out.WriteString("if " + value + " == nil {\n")
+			out.WriteString("dump = append(dump, " + quote(nilText) + "...)\n")
+			out.WriteString("} else {\n")
+			out.WriteString("dump, _ = tinyfmt.Appendf(dump, " + quote(address) + ", unsafe.Pointer(" + value + "))\n")
+			out.WriteString("}\n")
+		case field.isInterface && goSyntax:
+			out.WriteString("if " + value + " == nil {\n")
+			out.WriteString("dump = append(dump, " + quote(field.goType+"(nil)") + "...)\n")
+			out.WriteString("} else {\n")
+			out.WriteString("dump, _ = tinyfmt.Appendf(dump, " + quote(verb) + ", " + value + ")\n")
+			out.WriteString("}\n")
+		case verb == "%v":
+			out.WriteString("dump = tinyfmt.Append(dump, " + value + ")\n")
+		default:
+			out.WriteString("dump, _ = tinyfmt.Appendf(dump, " + quote(verb) + ", " + value + ")\n")
+		}
+	}
+	if len(st.fields) == 0 {
+		out.WriteString("dump = append(dump, " + quote(text) + "...)\n")
+	}
+	out.WriteString("dump = append(dump, '}')\n")
+}
+
+// quote returns s as a Go string literal.
+func quote(s string) string {
+	quoted, _ := tinyfmt.Sprintf("%q", s)
+	return quoted
+}
+
+// fail prints an error and exits.
+func fail(err error) {
+	os.Stderr.WriteString(err.Error() + "\n")
+	os.Exit(1)
+}
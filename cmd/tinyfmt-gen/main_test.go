@@ -0,0 +1,264 @@
+// =============================================================================
+// Project: tinyfmt
+// File: main_test.go
+// Description: Test suite for the tinyfmt-gen command.
+// Datasheet/Docs:
+//
+// Author: Jason Duffy
+// Created on: 18/10/2026
+//
+// Copyright: (C) 2026, Jason Duffy
+// License: See LICENSE file in the project root for full license information.
+// Disclaimer: See DISCLAIMER file in the project root for full disclaimer.
+// =============================================================================
+
+// -------------------------------------------------------------------------- //
+//                               Import Statement                             //
+// -------------------------------------------------------------------------- //
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// -------------------------------------------------------------------------- //
+//                           Variable Definitions                             //
+// -------------------------------------------------------------------------- //
+
+// testSource declares the types the tests generate methods for.
+const testSource = `package sensors
+
+import (
+	"bytes"
+	"io"
+	"time"
+)
+
+const depth = 2
+
+type Reading struct {
+	Sensor    string
+	Value     float64
+	raw, bias int16
+	mode      Mode
+	_         [2]byte
+	Status
+	*Calibration
+	Err error
+}
+
+type Status struct {
+	OK   bool
+	Code uint8
+}
+
+type Calibration struct {
+	Offset float32
+}
+
+type Mode uint8
+
+func (m Mode) String() string {
+	if m == 1 {
+		return "idle"
+	}
+	return "busy"
+}
+
+type Sink interface {
+	Write(p []byte) (int, error)
+}
+
+type Link struct {
+	Name   string
+	Out    Sink
+	Buffer *bytes.Buffer
+	Source io.Reader
+	Window *[depth]int
+}
+
+type Window[T any] struct {
+	Samples []T
+}
+
+type Duration time.Duration
+`
+
+// testCheck compares the generated output with tinyfmt's reflection-based
+// output for the same values, by copying them to types without the methods.
+const testCheck = `package sensors
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Jason-Duffy/tinyfmt"
+)
+
+type plainStatus Status
+
+type plainLink Link
+
+type plainReading struct {
+	Sensor      string
+	Value       float64
+	raw, bias   int16
+	mode        Mode
+	_           [2]byte
+	Status      plainStatus
+	Calibration *Calibration
+	Err         error
+}
+
+type codeError int
+
+func (e codeError) Error() string {
+	return "code " + tinyfmt.Sprint(int(e))
+}
+
+func TestGenerated(t *testing.T) {
+	status := Status{true, 7}
+	reading := Reading{Sensor: "adc0", Value: 3.25, raw: -12, bias: 4, mode: 1, Status: status}
+	calibrated := Reading{Sensor: "adc1", Calibration: &Calibration{0.5}, Err: codeError(3)}
+	link := Link{Name: "uart"}
+
+	for _, format := range []string{"%v", "%+v", "%#v", "%40v|", "%-30v|", "%.2v", "%040v|"} {
+		check(t, format, status, plainStatus(status))
+		check(t, format, link, plainLink(link))
+		for _, r := range []Reading{reading, calibrated} {
+			check(t, format, r, plainReading{
+				Sensor: r.Sensor, Value: r.Value, raw: r.raw, bias: r.bias, mode: r.mode,
+				Status: plainStatus(r.Status), Calibration: r.Calibration, Err: r.Err,
+			})
+		}
+	}
+
+	for _, testCase := range []struct {
+		format string
+		want   string
+	}{
+		{"%v", "{adc0 3.25 -12 4 1 [0 0] {true 7} <nil> <nil>}"},
+		{"%+v", "{Sensor:adc0 Value:3.25 raw:-12 bias:4 mode:1 _:[0 0] Status:{OK:true Code:7} Calibration:<nil> Err:<nil>}"},
+		{"%#v", "sensors.Reading{Sensor:\"adc0\", Value:3.25, raw:-12, bias:4, mode:0x1, _:[2]uint8{0x0, 0x0}, " +
+			"Status:sensors.Status{OK:true, Code:0x7}, Calibration:(*sensors.Calibration)(nil), Err:error(nil)}"},
+		{"%d", "%!d(sensors.Reading={adc0 3.25 -12 4 1 [0 0] {true 7} <nil> <nil>})"},
+	} {
+		if got := tinyfmt.SprintfLenient(testCase.format, reading); got != testCase.want {
+			t.Errorf("SprintfLenient(%q, reading) = %q, want %q", testCase.format, got, testCase.want)
+		}
+	}
+}
+
+// check compares a value's output with its reflection-based output, which
+// names the type without its methods.
+func check(t *testing.T, format string, value, plain interface{}) {
+	t.Helper()
+	got, err := tinyfmt.Sprintf(format, value)
+	want, _ := tinyfmt.Sprintf(format, plain)
+	want = strings.ReplaceAll(want, "sensors.plain", "sensors.")
+	if err != nil || got != want {
+		t.Errorf("Sprintf(%q, %T) = (%q, %v), want %q", format, value, got, err, want)
+	}
+}
+`
+
+// -------------------------------------------------------------------------- //
+//                              Public Functions                              //
+// -------------------------------------------------------------------------- //
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "sensors.go"), testSource)
+
+	src, err := generate(dir, []string{"Reading", "Status", "Link"})
+	if err != nil {
+		t.Fatalf("generate returned error: %v", err)
+	}
+	got := string(src)
+
+	for _, want := range []string{
+		"// Code generated by tinyfmt-gen; DO NOT EDIT.",
+		"package sensors",
+		"func (x Reading) Format(state tinyfmt.State, verb rune) {",
+		"func (x Status) Format(state tinyfmt.State, verb rune) {",
+		`dump = append(dump, " raw:"...)`,
+		`dump = append(dump, "sensors.Reading{Sensor:"...)`,
+		"dump = tinyfmt.Append(dump, x.Status)",
+		"dump = tinyfmt.Append(dump, uint8(x.mode))",
+		"dump = tinyfmt.Append(dump, *new([2]byte))",
+		`dump, _ = tinyfmt.Appendf(dump, "%+v", x.Status)`,
+		`dump, _ = tinyfmt.Appendf(dump, "(*sensors.Calibration)(%p)", unsafe.Pointer(x.Calibration))`,
+		`dump = append(dump, "error(nil)"...)`,
+		`dump = append(dump, "sensors.Sink(nil)"...)`,
+		`dump = append(dump, "(*bytes.Buffer)(nil)"...)`,
+		`dump = append(dump, "io.Reader(nil)"...)`,
+		`dump = append(dump, "(*[2]int)(nil)"...)`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("generated source does not contain %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "x._") {
+		t.Errorf("generated source reads a blank field:\n%s", got)
+	}
+
+	// Test types that are missing, generic or not structs are rejected
+	for _, name := range []string{"Missing", "Window", "Duration"} {
+		if _, err := generate(dir, []string{name}); err == nil {
+			t.Errorf("generate(%q) succeeded, want an error", name)
+		}
+	}
+}
+
+func TestGeneratedMatchesReflection(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds a module with the go command")
+	}
+	root, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum, err := os.ReadFile(filepath.Join(root, "go.sum"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A module that uses this checkout of tinyfmt
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "go.mod"), "module sensors\n\ngo 1.20\n\n"+
+		"require (\n\tgithub.com/Jason-Duffy/tinyfmt v0.0.0\n\tgithub.com/Jason-Duffy/tinystrconv v1.0.0\n)\n\n"+
+		"replace github.com/Jason-Duffy/tinyfmt => "+root+"\n")
+	writeFile(t, filepath.Join(dir, "go.sum"), string(sum))
+	writeFile(t, filepath.Join(dir, "sensors.go"), testSource)
+	writeFile(t, filepath.Join(dir, "check_test.go"), testCheck)
+
+	src, err := generate(dir, []string{"Reading", "Status", "Link"})
+	if err != nil {
+		t.Fatalf("generate returned error: %v", err)
+	}
+	writeFile(t, filepath.Join(dir, "reading_tinyfmt.go"), string(src))
+
+	cmd := exec.Command("go", "test", ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("go test of the generated code failed: %v\n%s", err, out)
+	}
+}
+
+// -------------------------------------------------------------------------- //
+//                          Private Helper Functions                          //
+// -------------------------------------------------------------------------- //
+
+// writeFile writes a test file, failing the test on error.
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
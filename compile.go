@@ -0,0 +1,164 @@
+// =============================================================================
+// Project: tinyfmt
+// File: compile.go
+// Description: Format strings parsed once and reused across calls.
+// Datasheet/Docs:
+//
+// Author: Jason Duffy
+// Created on: 18/10/2026
+//
+// Copyright: (C) 2026, Jason Duffy
+// License: See LICENSE file in the project root for full license information.
+// Disclaimer: See DISCLAIMER file in the project root for full disclaimer.
+// =============================================================================
+
+// -------------------------------------------------------------------------- //
+//                               Import Statement                             //
+// -------------------------------------------------------------------------- //
+
+package tinyfmt
+
+import (
+	"io"
+)
+
+// -------------------------------------------------------------------------- //
+//               Public Consts, Structs & Variable Definitions                //
+// -------------------------------------------------------------------------- //
+
+// Format is a format string parsed by Compile. Formatting with it skips the
+// parsing Sprintf does on every call, and gives the same output and errors.
+// A Format is safe for concurrent use.
+type Format struct {
+	format string        // The source format string
+	pieces []formatPiece // Directives, each with the text before it
+	tail   string        // Text after the last directive
+}
+
+// -------------------------------------------------------------------------- //
+//               Private Consts, Structs & Variable Definitions               //
+// -------------------------------------------------------------------------- //
+
+// formatPiece is a directive of a compiled format and the literal text that
+// comes before it.
+type formatPiece struct {
+	literal   string    // Text written before the directive
+	directive directive // The parsed directive
+	offset    int       // Byte offset of the directive's '%'
+}
+
+// -------------------------------------------------------------------------- //
+//                              Public Functions                              //
+// -------------------------------------------------------------------------- //
+
+// Compile parses a format string for repeated use. It reports the problems
+// that do not depend on the arguments as a *FormatError: a directive cut off
+// by the end of the string (IncompleteDirective), a malformed or misplaced
+// [n] index (BadArgumentIndex) and %w, which only Errorf supports (BadVerb).
+func Compile(format string) (*Format, error) {
+	f := &Format{format: format}
+	literalStart := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+
+		// A plain "%%" stays in the literal text, as its first '%'
+		if i+1 < len(format) && format[i+1] == '%' {
+			f.pieces = append(f.pieces, formatPiece{literal: format[literalStart : i+1], offset: -1})
+			literalStart = i + 2
+			i++
+			continue
+		}
+
+		d, next := parseDirective(format, i+1)
+		switch {
+		case d.verb == 0:
+			return nil, newFormatError(IncompleteDirective, i, 0, -1, nil)
+		case d.verb == '%':
+		case d.badIndex:
+			return nil, newFormatError(BadArgumentIndex, i, d.verb, -1, nil)
+		case d.verb == 'w':
+			return nil, newFormatError(BadVerb, i, d.verb, -1, nil)
+		}
+
+		f.pieces = append(f.pieces, formatPiece{literal: format[literalStart:i], directive: d, offset: i})
+		literalStart = next + 1
+		i = next
+	}
+	f.tail = format[literalStart:]
+	return f, nil
+}
+
+// MustCompile is like Compile but panics if the format string is bad. It is
+// meant for formats held in package variables.
+func MustCompile(format string) *Format {
+	f, err := Compile(format)
+	if err != nil {
+		panic("tinyfmt: Compile(" + format + "): " + err.Error())
+	}
+	return f
+}
+
+// Sprintf formats the arguments like the package's Sprintf.
+func (f *Format) Sprintf(arguments ...interface{}) (string, error) {
+	p := newPrinter()
+	defer p.free()
+	if err := p.printFormat(f, arguments); err != nil {
+		return "", err
+	}
+	return string(p.buf), nil
+}
+
+// Append formats the arguments like Appendf, appending to dst.
+func (f *Format) Append(dst []byte, arguments ...interface{}) ([]byte, error) {
+	p := printer{buf: dst}
+	if err := p.printFormat(f, arguments); err != nil {
+		return dst, err
+	}
+	return p.buf, nil
+}
+
+// Fprintf formats the arguments like the package's Fprintf, writing to w.
+func (f *Format) Fprintf(w io.Writer, arguments ...interface{}) (n int, err error) {
+	p := newPrinter()
+	defer p.free()
+	if err := p.printFormat(f, arguments); err != nil {
+		return 0, err
+	}
+	return write(w, p.buf)
+}
+
+// -------------------------------------------------------------------------- //
+//                              Getters / Setters                             //
+// -------------------------------------------------------------------------- //
+
+// String returns the source format string.
+func (f *Format) String() string {
+	return f.format
+}
+
+// -------------------------------------------------------------------------- //
+//                          Private Helper Functions                          //
+// -------------------------------------------------------------------------- //
+
+// printFormat appends the arguments formatted according to a compiled format
+// to p.buf. It mirrors printf, without the parsing.
+func (p *printer) printFormat(f *Format, arguments []interface{}) error {
+	p.argNum = 0
+	p.reordered = false
+
+	for i := range f.pieces {
+		piece := &f.pieces[i]
+		p.buf = append(p.buf, piece.literal...)
+		if piece.offset < 0 {
+			continue // Literal text only
+		}
+		if err := p.printDirective(&piece.directive, piece.offset, arguments); err != nil {
+			return err
+		}
+	}
+	p.buf = append(p.buf, f.tail...)
+
+	return p.checkExtra(len(f.format), arguments)
+}
@@ -0,0 +1,178 @@
+// =============================================================================
+// Project: tinyfmt
+// File: compile_test.go
+// Description: Test suite and benchmarks for compiled formats in tinyfmt package.
+// Datasheet/Docs:
+//
+// Author: Jason Duffy
+// Created on: 18/10/2026
+//
+// Copyright: (C) 2026, Jason Duffy
+// License: See LICENSE file in the project root for full license information.
+// Disclaimer: See DISCLAIMER file in the project root for full disclaimer.
+// =============================================================================
+
+// -------------------------------------------------------------------------- //
+//                               Import Statement                             //
+// -------------------------------------------------------------------------- //
+
+package tinyfmt
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// -------------------------------------------------------------------------- //
+//                              Public Functions                              //
+// -------------------------------------------------------------------------- //
+
+func TestCompileMatchesSprintf(t *testing.T) {
+	testCases := []struct {
+		format    string
+		arguments []interface{}
+	}{
+		{"", nil},                                                // Test empty format
+		{"no directives", nil},                                   // Test literal text only
+		{"100%% sure, %d%%", []interface{}{5}},                   // Test literal percents
+		{"%d %s %v", []interface{}{1, "two", 3.0}},               // Test several directives
+		{"[%-6s|%6.2f]", []interface{}{"temp", 21.456}},          // Test flags, width and precision
+		{"%[2]d %[1]d %d", []interface{}{1, 2}},                  // Test argument indexes
+		{"%*.*f|%-*d|", []interface{}{8, 2, 3.14159, 4, 7}},      // Test star width and precision
+		{"%5%|%*%", []interface{}{3}},                            // Test percent directives
+		{"id=%d", []interface{}{"x"}},                            // Test wrong argument type
+		{"%d %d", []interface{}{1}},                              // Test missing argument
+		{"%d", []interface{}{1, 2}},                              // Test extra arguments
+		{"%[3]d", []interface{}{1}},                              // Test index out of range
+		{"%*d", []interface{}{"w", 1}},                           // Test bad width
+		{"%z", []interface{}{1}},                                 // Test unknown verb
+		{"a%éb", []interface{}{1}},                               // Test multibyte unknown verb
+		{"%v %.1v", []interface{}{testState(1), testFixed(384)}}, // Test Stringer and Formatter arguments
+	}
+	for _, testCase := range scalarCases {
+		testCases = append(testCases, struct {
+			format    string
+			arguments []interface{}
+		}{testCase.format, testCase.arguments})
+	}
+
+	for _, testCase := range testCases {
+		f, err := Compile(testCase.format)
+		if err != nil {
+			t.Errorf("Compile(%q) returned error: %v", testCase.format, err)
+			continue
+		}
+		if f.String() != testCase.format {
+			t.Errorf("Compile(%q).String() = %q", testCase.format, f.String())
+		}
+
+		want, wantErr := Sprintf(testCase.format, testCase.arguments...)
+		got, err := f.Sprintf(testCase.arguments...)
+		if got != want || !sameFormatError(err, wantErr) {
+			t.Errorf("Compile(%q).Sprintf(%v) = (%q, %v), want (%q, %v)", testCase.format, testCase.arguments, got, err, want, wantErr)
+		}
+
+		appended, err := f.Append([]byte("> "), testCase.arguments...)
+		if wantErr == nil && string(appended) != "> "+want || !sameFormatError(err, wantErr) {
+			t.Errorf("Compile(%q).Append(%v) = (%q, %v), want (%q, %v)", testCase.format, testCase.arguments, appended, err, "> "+want, wantErr)
+		}
+
+		var buf bytes.Buffer
+		n, err := f.Fprintf(&buf, testCase.arguments...)
+		if buf.String() != want || n != len(want) || !sameFormatError(err, wantErr) {
+			t.Errorf("Compile(%q).Fprintf(%v) = (%q, %d, %v), want (%q, %v)", testCase.format, testCase.arguments, buf.String(), n, err, want, wantErr)
+		}
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	testCases := []struct {
+		format string
+		want   FormatError
+	}{
+		{"value: %-5", FormatError{IncompleteDirective, 7, 0, -1, ""}}, // Test incomplete directive
+		{"%d %[x]d", FormatError{BadArgumentIndex, 3, 'd', -1, ""}},    // Test malformed index
+		{"%[1]5d", FormatError{BadArgumentIndex, 0, 'd', -1, ""}},      // Test misplaced index
+		{"%[0]s", FormatError{BadArgumentIndex, 0, 's', -1, ""}},       // Test zero index
+		{"failed: %w", FormatError{BadVerb, 8, 'w', -1, ""}},           // Test %w outside Errorf
+	}
+
+	for _, testCase := range testCases {
+		f, err := Compile(testCase.format)
+		var formatErr *FormatError
+		if f != nil || !errors.As(err, &formatErr) || *formatErr != testCase.want {
+			t.Errorf("Compile(%q) = (%v, %v), want a *FormatError %+v", testCase.format, f, err, testCase.want)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustCompile with a bad format did not panic")
+		}
+	}()
+	MustCompile("%")
+}
+
+func TestCompiledAllocs(t *testing.T) {
+	buf := make([]byte, 0, 64)
+	for _, testCase := range scalarCases {
+		f := MustCompile(testCase.format)
+		allocs := testing.AllocsPerRun(100, func() {
+			f.Append(buf[:0], testCase.arguments...)
+		})
+		if allocs != 0 {
+			t.Errorf("Compile(%q).Append(%v) allocated %v times, want 0", testCase.format, testCase.arguments, allocs)
+		}
+	}
+}
+
+func BenchmarkCompiledSprintf(b *testing.B) {
+	f := MustCompile("sensor %s: reading=%04d voltage=%.2fV status=%v")
+	arguments := []interface{}{"adc0", 1023, 3.3, true}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		f.Sprintf(arguments...)
+	}
+}
+
+func BenchmarkUncompiledSprintf(b *testing.B) {
+	arguments := []interface{}{"adc0", 1023, 3.3, true}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Sprintf("sensor %s: reading=%04d voltage=%.2fV status=%v", arguments...)
+	}
+}
+
+func BenchmarkCompiledAppend(b *testing.B) {
+	f := MustCompile("sensor %s: reading=%04d voltage=%.2fV status=%v")
+	buf := make([]byte, 0, 64)
+	arguments := []interface{}{"adc0", 1023, 3.3, true}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf, _ = f.Append(buf[:0], arguments...)
+	}
+}
+
+func BenchmarkUncompiledAppendf(b *testing.B) {
+	buf := make([]byte, 0, 64)
+	arguments := []interface{}{"adc0", 1023, 3.3, true}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf, _ = Appendf(buf[:0], "sensor %s: reading=%04d voltage=%.2fV status=%v", arguments...)
+	}
+}
+
+// -------------------------------------------------------------------------- //
+//                             Private Functions                              //
+// -------------------------------------------------------------------------- //
+
+// sameFormatError reports whether two errors are both nil or equal
+// *FormatErrors.
+func sameFormatError(err, want error) bool {
+	if err == nil || want == nil {
+		return err == nil && want == nil
+	}
+	var got, expected *FormatError
+	return errors.As(err, &got) && errors.As(want, &expected) && *got == *expected
+}
@@ -22,15 +22,178 @@ import (
 	"errors"
 )
 
+// -------------------------------------------------------------------------- //
+//               Public Consts, Structs & Variable Definitions                //
+// -------------------------------------------------------------------------- //
+
+// FormatErrorKind identifies what was wrong with a directive.
+type FormatErrorKind int
+
+const (
+	// MissingArgument means a directive had no argument left to format.
+	MissingArgument FormatErrorKind = iota + 1
+
+	// WrongArgumentType means the argument's type does not suit the verb.
+	WrongArgumentType
+
+	// BadVerb means the verb is not supported.
+	BadVerb
+
+	// ExtraArguments means arguments were left over after the format string.
+	// The error's ArgIndex and ArgType describe the first unused argument.
+	ExtraArguments
+
+	// IncompleteDirective means the format string ended inside a directive.
+	IncompleteDirective
+
+	// BadArgumentIndex means an explicit [n] index was malformed or out of range.
+	BadArgumentIndex
+
+	// BadWidth means a '*' width had no integer argument.
+	BadWidth
+
+	// BadPrecision means a '*' precision had no non-negative integer argument.
+	BadPrecision
+)
+
+// FormatError describes a directive that could not be formatted. It is
+// returned by Sprintf, PrintToIo, Printf and Errorf.
+type FormatError struct {
+	Kind     FormatErrorKind // What was wrong
+	Offset   int             // Byte offset of the directive's '%', or the format's length for ExtraArguments
+	Verb     rune            // The directive's verb, 0 if there is none
+	ArgIndex int             // Index of the argument, -1 if there is none
+	ArgType  string          // Type of the argument, empty if there is none
+}
+
+// -------------------------------------------------------------------------- //
+//               Private Consts, Structs & Variable Definitions               //
+// -------------------------------------------------------------------------- //
+
+// formatOK is the kind reported when an argument was formatted successfully.
+const formatOK FormatErrorKind = 0
+
+// wrapError is returned by Errorf when the format has one %w verb.
+type wrapError struct {
+	message string
+	err     error
+}
+
+// wrapErrors is returned by Errorf when the format has several %w verbs.
+type wrapErrors struct {
+	message string
+	errs    []error
+}
+
 // -------------------------------------------------------------------------- //
 //                              Public Functions                              //
 // -------------------------------------------------------------------------- //
 
 // Errorf formats according to a format specifier and returns the string as a value that satisfies error.
+// An error operand of the %w verb is wrapped, so errors.Is, errors.As and errors.Unwrap can see it;
+// with several %w verbs the error has an Unwrap() []error method instead.
 func Errorf(format string, arguments ...interface{}) error {
-	result, err := Sprintf(format, arguments...)
-	if err != nil {
+	p := newPrinter()
+	defer p.free()
+	p.wrapErrs = true
+	if err := p.printf(format, arguments); err != nil {
 		return err
 	}
-	return errors.New(result)
+	message := string(p.buf)
+
+	switch len(p.wrapped) {
+	case 0:
+		return errors.New(message)
+	case 1:
+		return &wrapError{message: message, err: p.wrapped[0]}
+	default:
+		return &wrapErrors{message: message, errs: p.wrapped}
+	}
+}
+
+// -------------------------------------------------------------------------- //
+//                              Getters / Setters                             //
+// -------------------------------------------------------------------------- //
+
+// Error describes the bad directive.
+func (e *FormatError) Error() string {
+	verb := "%" + string(e.Verb)
+	switch e.Kind {
+	case MissingArgument:
+		return "missing argument for " + verb
+	case WrongArgumentType:
+		return "argument for " + verb + " is not " + expectedArgument(e.Verb)
+	case BadVerb:
+		if e.Verb == 'w' {
+			return "%w is only supported by Errorf"
+		}
+		return "unsupported format specifier"
+	case ExtraArguments:
+		return "extra arguments not used by the format string"
+	case IncompleteDirective:
+		return "incomplete format specifier at end of string"
+	case BadArgumentIndex:
+		return "bad argument index for " + verb
+	case BadWidth:
+		return "width argument is not an integer"
+	case BadPrecision:
+		return "precision argument is not a non-negative integer"
+	default:
+		return "bad format"
+	}
+}
+
+// Error returns the formatted message.
+func (e *wrapError) Error() string {
+	return e.message
+}
+
+// Unwrap returns the error wrapped by %w.
+func (e *wrapError) Unwrap() error {
+	return e.err
+}
+
+// Error returns the formatted message.
+func (e *wrapErrors) Error() string {
+	return e.message
+}
+
+// Unwrap returns the errors wrapped by each %w, in order.
+func (e *wrapErrors) Unwrap() []error {
+	return e.errs
+}
+
+// -------------------------------------------------------------------------- //
+//                          Private Helper Functions                          //
+// -------------------------------------------------------------------------- //
+
+// newFormatError builds a *FormatError, naming the argument's type.
+func newFormatError(kind FormatErrorKind, offset int, verb rune, argIndex int, argument interface{}) *FormatError {
+	err := &FormatError{Kind: kind, Offset: offset, Verb: verb, ArgIndex: argIndex}
+	if argIndex >= 0 && kind != MissingArgument {
+		err.ArgType = typeName(argument)
+	}
+	return err
+}
+
+// expectedArgument describes the kind of argument a verb accepts.
+func expectedArgument(verb rune) string {
+	switch verb {
+	case 'd', 'b', 'o', 'x', 'X':
+		return "an integer"
+	case 'e', 'E', 'f', 'F', 'g', 'G':
+		return "a float"
+	case 's':
+		return "a string"
+	case 'q':
+		return "a string or rune"
+	case 'c', 'U':
+		return "a rune"
+	case 'p':
+		return "a pointer"
+	case 'w':
+		return "an error"
+	default:
+		return "valid"
+	}
 }
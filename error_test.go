@@ -20,6 +20,7 @@ package tinyfmt
 
 import (
 	"errors"
+	"io"
 	"testing"
 )
 
@@ -33,15 +34,14 @@ func TestErrorf(t *testing.T) {
 		arguments []interface{}
 		want      string
 	}{
-		{"Error: %s", []interface{}{"something went wrong"}, "Error: something went wrong"}, // Test formatting a string error message
-		{"Code: %d", []interface{}{404}, "Code: 404"},                                       // Test formatting an integer error code
-		{"Invalid: %q", []interface{}{42}, "unsupported format specifier"},                  // Test with unsupported format specifier
-		{"Missing arg: %d %d", []interface{}{42}, "missing argument for %d"},                // Test with missing argument
-		{"", []interface{}{}, ""},                                                                      // Test with empty format string
-		{"Nil arg: %v", []interface{}{nil}, "Nil arg: <unsupported>"},                                  // Test with nil argument
-		{"Multiple: %d, %s, %v", []interface{}{42, "test", true}, "Multiple: 42, test, true"},          // Test with multiple format specifiers
-		{"Unsupported type: %v", []interface{}{map[string]int{"key": 1}}, "Unsupported type: {key:1}"}, // Test with unsupported type
-		{"Percent sign: %%", []interface{}{}, "Percent sign: %"},                                       // Test with escaped percent sign
+		{"Error: %s", []interface{}{"something went wrong"}, "Error: something went wrong"},   // Test formatting a string error message
+		{"Code: %d", []interface{}{404}, "Code: 404"},                                         // Test formatting an integer error code
+		{"Invalid: %z", []interface{}{42}, "unsupported format specifier"},                    // Test with unsupported format specifier
+		{"Missing arg: %d %d", []interface{}{42}, "missing argument for %d"},                  // Test with missing argument
+		{"", []interface{}{}, ""},                                                             // Test with empty format string
+		{"Nil arg: %v", []interface{}{nil}, "Nil arg: <nil>"},                                 // Test with nil argument
+		{"Multiple: %d, %s, %v", []interface{}{42, "test", true}, "Multiple: 42, test, true"}, // Test with multiple format specifiers
+		{"Percent sign: %%", []interface{}{}, "Percent sign: %"},                              // Test with escaped percent sign
 	}
 
 	for _, testCase := range testCases {
@@ -66,3 +66,115 @@ func TestErrorfWithErrorsNew(t *testing.T) {
 		t.Errorf("Errorf generated error = %q, want %q", err1.Error(), expected)
 	}
 }
+
+type testCodeError struct {
+	code int
+}
+
+func (e *testCodeError) Error() string {
+	return "code " + Sprint(e.code)
+}
+
+func TestErrorfWrap(t *testing.T) {
+	errTimeout := errors.New("timeout")
+	errCRC := errors.New("crc mismatch")
+
+	// Test a single %w
+	err := Errorf("uart read: %w", errTimeout)
+	if err.Error() != "uart read: timeout" {
+		t.Errorf("Errorf(%%w) = %q, want %q", err.Error(), "uart read: timeout")
+	}
+	if !errors.Is(err, errTimeout) {
+		t.Errorf("errors.Is(Errorf(%%w), errTimeout) = false, want true")
+	}
+	if errors.Unwrap(err) != errTimeout {
+		t.Errorf("errors.Unwrap(Errorf(%%w)) = %v, want %v", errors.Unwrap(err), errTimeout)
+	}
+
+	// Test errors.As through a wrapped custom error
+	err = Errorf("sensor %d: %w", 3, &testCodeError{7})
+	var codeErr *testCodeError
+	if !errors.As(err, &codeErr) || codeErr.code != 7 {
+		t.Errorf("errors.As(Errorf(%%w), *testCodeError) failed, got %v", codeErr)
+	}
+	if err.Error() != "sensor 3: code 7" {
+		t.Errorf("Errorf(%%w) = %q, want %q", err.Error(), "sensor 3: code 7")
+	}
+
+	// Test several %w verbs
+	err = Errorf("%w and %w", errTimeout, errCRC)
+	if err.Error() != "timeout and crc mismatch" {
+		t.Errorf("Errorf(%%w %%w) = %q, want %q", err.Error(), "timeout and crc mismatch")
+	}
+	if !errors.Is(err, errTimeout) || !errors.Is(err, errCRC) {
+		t.Errorf("errors.Is(Errorf(%%w %%w)) did not find both wrapped errors")
+	}
+	if errors.Unwrap(err) != nil {
+		t.Errorf("errors.Unwrap(Errorf(%%w %%w)) = %v, want nil", errors.Unwrap(err))
+	}
+	if unwrapper, ok := err.(interface{ Unwrap() []error }); !ok || len(unwrapper.Unwrap()) != 2 {
+		t.Errorf("Errorf(%%w %%w) does not implement Unwrap() []error with two errors")
+	}
+
+	// Test without %w nothing is wrapped
+	err = Errorf("plain: %v", errTimeout)
+	if errors.Is(err, errTimeout) {
+		t.Errorf("errors.Is(Errorf(%%v), errTimeout) = true, want false")
+	}
+
+	// Test a non-error operand for %w
+	err = Errorf("bad: %w", 42)
+	if err.Error() != "argument for %w is not an error" {
+		t.Errorf("Errorf(%%w, 42) = %q, want %q", err.Error(), "argument for %w is not an error")
+	}
+
+	// Test %w is rejected outside Errorf
+	if _, err := Sprintf("%w", errTimeout); err == nil {
+		t.Errorf("Sprintf(%%w) error = nil, want an error")
+	}
+}
+
+func TestFormatError(t *testing.T) {
+	testCases := []struct {
+		format    string
+		arguments []interface{}
+		want      FormatError
+		message   string
+	}{
+		{"a=%d b=%d", []interface{}{1}, FormatError{MissingArgument, 7, 'd', 1, ""}, "missing argument for %d"},                              // Test missing argument
+		{"id %-4d", []interface{}{"x"}, FormatError{WrongArgumentType, 3, 'd', 0, "string"}, "argument for %d is not an integer"},            // Test wrong type
+		{"%s %.2f", []interface{}{"t", 3}, FormatError{WrongArgumentType, 3, 'f', 1, "int"}, "argument for %f is not a float"},               // Test wrong type for float
+		{"%v %z", []interface{}{1, 2}, FormatError{BadVerb, 3, 'z', 1, "int"}, "unsupported format specifier"},                               // Test bad verb
+		{"a%éb", []interface{}{1}, FormatError{BadVerb, 1, 'é', 0, "int"}, "unsupported format specifier"},                                   // Test multibyte bad verb
+		{"%s", []interface{}{nil}, FormatError{WrongArgumentType, 0, 's', 0, "<nil>"}, "argument for %s is not a string"},                    // Test nil argument
+		{"end %-5", []interface{}{1}, FormatError{IncompleteDirective, 4, 0, -1, ""}, "incomplete format specifier at end of string"},        // Test incomplete directive
+		{"id=%d", []interface{}{1, "x", 2}, FormatError{ExtraArguments, 5, 0, 1, "string"}, "extra arguments not used by the format string"}, // Test extra arguments
+		{"%[3]d", []interface{}{1, 2}, FormatError{BadArgumentIndex, 0, 'd', -1, ""}, "bad argument index for %d"},                           // Test index out of range
+		{"x %[1]5d", []interface{}{1}, FormatError{BadArgumentIndex, 2, 'd', -1, ""}, "bad argument index for %d"},                           // Test misplaced index
+		{"%*d", []interface{}{"w", 1}, FormatError{BadWidth, 0, 0, 0, "string"}, "width argument is not an integer"},                         // Test bad width argument
+		{"%.*f", []interface{}{-2, 1.5}, FormatError{BadPrecision, 0, 0, 0, "int"}, "precision argument is not a non-negative integer"},      // Test negative precision argument
+	}
+
+	for _, testCase := range testCases {
+		_, err := Sprintf(testCase.format, testCase.arguments...)
+		var formatErr *FormatError
+		if !errors.As(err, &formatErr) {
+			t.Errorf("Sprintf(%q, %v) error = %v, want a *FormatError", testCase.format, testCase.arguments, err)
+			continue
+		}
+		if *formatErr != testCase.want {
+			t.Errorf("Sprintf(%q, %v) error = %+v, want %+v", testCase.format, testCase.arguments, *formatErr, testCase.want)
+		}
+		if formatErr.Error() != testCase.message {
+			t.Errorf("Sprintf(%q, %v) error message = %q, want %q", testCase.format, testCase.arguments, formatErr.Error(), testCase.message)
+		}
+
+		// The same error comes back unchanged from the other entry points
+		if err := PrintToIo(io.Discard, testCase.format, testCase.arguments...); !errors.As(err, &formatErr) || *formatErr != testCase.want {
+			t.Errorf("PrintToIo(%q, %v) error = %v, want %+v", testCase.format, testCase.arguments, err, testCase.want)
+		}
+		if err := Errorf(testCase.format, testCase.arguments...); !errors.As(err, &formatErr) || *formatErr != testCase.want {
+			t.Errorf("Errorf(%q, %v) error = %v, want %+v", testCase.format, testCase.arguments, err, testCase.want)
+		}
+	}
+}
@@ -0,0 +1,332 @@
+// =============================================================================
+// Project: tinyfmt
+// File: fixed.go
+// Description: A fixed-capacity buffer for formatting without heap allocation.
+// Datasheet/Docs:
+//
+// Author: Jason Duffy
+// Created on: 18/10/2026
+//
+// Copyright: (C) 2026, Jason Duffy
+// License: See LICENSE file in the project root for full license information.
+// Disclaimer: See DISCLAIMER file in the project root for full disclaimer.
+// =============================================================================
+
+// -------------------------------------------------------------------------- //
+//                               Import Statement                             //
+// -------------------------------------------------------------------------- //
+
+package tinyfmt
+
+import (
+	"io"
+	"math"
+	"unicode/utf8"
+)
+
+// -------------------------------------------------------------------------- //
+//               Public Consts, Structs & Variable Definitions                //
+// -------------------------------------------------------------------------- //
+
+// FixedBuffer formats into caller-owned memory that never grows. Output that
+// does not fit is truncated, at a rune boundary, and the overflow is recorded
+// until Reset. Text, numbers, runes and bools are formatted straight into the
+// storage without allocating, even when they overflow, which makes it
+// suitable for interrupt handlers and builds without a garbage collector.
+// Formatters, Stringers and composite values may still allocate, as may
+// boxing non-constant arguments into interfaces at the call site; pass values
+// that are already interfaces, or small integers, to avoid that.
+type FixedBuffer struct {
+	buf      []byte // Caller memory, with the written bytes as its length
+	overflow bool   // Whether output has been dropped since the last Reset
+}
+
+// -------------------------------------------------------------------------- //
+//               Private Consts, Structs & Variable Definitions               //
+// -------------------------------------------------------------------------- //
+
+// integerBound is the most bytes an integer takes without its width and
+// precision: a sign, a prefix and 64 binary digits, which is more than a
+// quoted rune or U+ code point.
+const integerBound = 67
+
+// fixedScratch is the size of the stack buffer that a scalar is formatted
+// into when it may not fit in what is left of a FixedBuffer, so it can be cut
+// short without the storage growing.
+const fixedScratch = 512
+
+// -------------------------------------------------------------------------- //
+//                              Public Functions                              //
+// -------------------------------------------------------------------------- //
+
+// NewFixedBuffer returns a FixedBuffer that formats into storage, using its
+// full capacity. Any bytes already in storage are discarded.
+func NewFixedBuffer(storage []byte) *FixedBuffer {
+	return &FixedBuffer{buf: storage[:0]}
+}
+
+// Printf formats according to a format specifier like Sprintf and appends
+// the result to the buffer, truncating it if the buffer is full. A
+// formatting error is returned as a *FormatError and nothing is written.
+func (b *FixedBuffer) Printf(format string, arguments ...interface{}) error {
+	p := printer{buf: b.buf, fixed: true}
+	if err := p.printf(format, arguments); err != nil {
+		return err
+	}
+	b.finish(&p)
+	return nil
+}
+
+// Print formats the arguments like Sprint and appends the result to the
+// buffer, truncating it if the buffer is full.
+func (b *FixedBuffer) Print(arguments ...interface{}) {
+	p := printer{buf: b.buf, fixed: true}
+	p.print(arguments)
+	b.finish(&p)
+}
+
+// Println formats the arguments like Sprintln and appends the result to the
+// buffer, truncating it if the buffer is full.
+func (b *FixedBuffer) Println(arguments ...interface{}) {
+	p := printer{buf: b.buf, fixed: true}
+	p.println(arguments)
+	b.finish(&p)
+}
+
+// Write appends data to the buffer so it can be used as an io.Writer. If the
+// data does not fit, what fits is written and io.ErrShortWrite is returned.
+func (b *FixedBuffer) Write(data []byte) (int, error) {
+	n := b.append(data)
+	if n < len(data) {
+		return n, io.ErrShortWrite
+	}
+	return n, nil
+}
+
+// Reset empties the buffer and clears the overflow flag, keeping the storage.
+func (b *FixedBuffer) Reset() {
+	b.buf = b.buf[:0]
+	b.overflow = false
+}
+
+// -------------------------------------------------------------------------- //
+//                              Getters / Setters                             //
+// -------------------------------------------------------------------------- //
+
+// Bytes returns the formatted output. It aliases the buffer's storage and is
+// only valid until the next write or Reset.
+func (b *FixedBuffer) Bytes() []byte {
+	return b.buf
+}
+
+// String returns the formatted output as a string. Unlike the rest of
+// FixedBuffer it allocates.
+func (b *FixedBuffer) String() string {
+	return string(b.buf)
+}
+
+// Len returns the number of bytes written.
+func (b *FixedBuffer) Len() int {
+	return len(b.buf)
+}
+
+// Cap returns the capacity of the buffer's storage.
+func (b *FixedBuffer) Cap() int {
+	return cap(b.buf)
+}
+
+// Overflow reports whether any output has been truncated since the last
+// Reset.
+func (b *FixedBuffer) Overflow() bool {
+	return b.overflow
+}
+
+// -------------------------------------------------------------------------- //
+//                          Private Helper Functions                          //
+// -------------------------------------------------------------------------- //
+
+// append copies as much of data as fits into the buffer, returning the number
+// of bytes copied. Truncated output ends on a rune boundary, so the buffer
+// always holds valid UTF-8 if the data was.
+func (b *FixedBuffer) append(data []byte) int {
+	cut := runeCut(data, cap(b.buf)-len(b.buf))
+	if cut < len(data) {
+		b.overflow = true
+	}
+	b.buf = append(b.buf, data[:cut]...)
+	return cut
+}
+
+// finish keeps the output of a fixed printer that formatted into the buffer's
+// storage.
+func (b *FixedBuffer) finish(p *printer) {
+	b.buf = p.buf
+	if p.overflow {
+		b.overflow = true
+	}
+}
+
+// writeArg formats one argument with printArg. A fixed printer formats a
+// scalar in place when it is sure to fit, or in a scratch buffer on the
+// stack when it may not, so its storage never grows. Anything else is
+// formatted in place and then cut back to the storage, which allocates only
+// if it overflows.
+func (p *printer) writeArg(argument interface{}, verb rune, spec formatSpec) FormatErrorKind {
+	if !p.fixed {
+		return p.printArg(argument, verb, spec)
+	}
+
+	if _, ok := argument.(Formatter); !ok && verb < utf8.RuneSelf {
+		if spec == defaultSpec {
+			switch value := argument.(type) {
+			case string:
+				if verb == 's' || verb == 'v' {
+					p.writeString(value)
+					return formatOK
+				}
+			case []byte:
+				if verb == 's' {
+					p.buf = append(p.buf, value[:fixedCut(p, value)]...)
+					return formatOK
+				}
+			}
+		}
+		bound := scalarBound(argument, byte(verb), spec)
+		switch {
+		case bound < 0:
+		case p.overflow:
+			return formatOK // Nothing more is written, and a scalar cannot fail
+		case bound <= cap(p.buf)-len(p.buf):
+			p.buf, _ = appendScalar(p.buf, argument, byte(verb), spec)
+			return formatOK
+		case bound <= fixedScratch:
+			var scratch [fixedScratch]byte
+			out, _ := appendScalar(scratch[:0], argument, byte(verb), spec)
+			p.buf = append(p.buf, out[:fixedCut(p, out)]...)
+			return formatOK
+		}
+	}
+
+	start := len(p.buf)
+	storage := p.buf
+	kind := p.printArg(argument, verb, spec)
+	out := p.buf[start:]
+	p.buf = storage
+	p.buf = append(p.buf, out[:fixedCut(p, out)]...)
+	return kind
+}
+
+// fixedCut returns how much of data a fixed printer can still write. Once
+// output has been cut nothing more is written, so it never resumes after a
+// gap.
+func fixedCut[T string | []byte](p *printer, data T) int {
+	if p.overflow {
+		return 0
+	}
+	cut := runeCut(data, cap(p.buf)-len(p.buf))
+	p.overflow = cut < len(data)
+	return cut
+}
+
+// runeCut returns how many bytes of data fit in free bytes, backing off to a
+// rune boundary so a rune is never split.
+func runeCut[T string | []byte](data T, free int) int {
+	if len(data) <= free {
+		return len(data)
+	}
+	cut := free
+	for back := 0; back < utf8.UTFMax && cut > 0 && !utf8.RuneStart(data[cut]); back++ {
+		cut--
+	}
+	if !utf8.RuneStart(data[cut]) {
+		cut = free // Not UTF-8 text, so cut at the byte
+	}
+	return cut
+}
+
+// scalarBound returns the most bytes appendScalar writes for an argument and
+// verb, or -1 if appendScalar does not handle them. It follows the same cases
+// as appendScalar, and only needs to be safe, not tight.
+func scalarBound(argument interface{}, verb byte, spec formatSpec) int {
+	extra := 0
+	if spec.width > 0 {
+		extra += spec.width
+	}
+	if spec.precision > 0 {
+		extra += spec.precision
+	}
+
+	if verb == 'v' {
+		if spec.plus || spec.sharp {
+			return -1
+		}
+		switch value := argument.(type) {
+		case nil, bool:
+			return extra + len("false")
+		case string:
+			return extra + len(value)
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr:
+			return extra + integerBound
+		case float32:
+			return extra + floatBound(float64(value))
+		case float64:
+			return extra + floatBound(value)
+		case complex64:
+			return complexBound(complex128(value), extra)
+		case complex128:
+			return complexBound(value, extra)
+		}
+		return -1
+	}
+
+	if _, _, ok := integerArgument(argument); ok {
+		switch verb {
+		case 'd', 'b', 'x', 'X', 'o', 'q', 'c', 'U':
+			return extra + integerBound
+		}
+		return -1
+	}
+
+	switch verb {
+	case 'e', 'E', 'f', 'F', 'g', 'G':
+		if value, _, ok := floatArgument(argument); ok {
+			return extra + floatBound(value)
+		}
+		if value, _, ok := complexArgument(argument); ok {
+			return complexBound(value, extra)
+		}
+	case 's':
+		switch value := argument.(type) {
+		case string:
+			return extra + len(value)
+		case []byte:
+			return extra + len(value)
+		}
+	case 'q', 'x', 'X':
+		// Escapes and spaced hex take at most 5 bytes for each byte
+		switch value := argument.(type) {
+		case string:
+			return extra + 5*len(value) + 2
+		case []byte:
+			return extra + 5*len(value) + 2
+		}
+	}
+	return -1
+}
+
+// floatBound returns the most bytes appendFloat writes for a value, less the
+// width and precision: a sign, a point, up to 17 significant digits and an
+// exponent or leading zeros, plus the digits before the point of %f.
+func floatBound(value float64) int {
+	n := 32
+	if _, exp := math.Frexp(value); exp > 0 {
+		n += exp*31/100 + 1 // 31/100 rounds log10(2) up
+	}
+	return n
+}
+
+// complexBound returns the most bytes appendComplex writes for a value, where
+// extra is the width and precision, which apply to both parts.
+func complexBound(value complex128, extra int) int {
+	return 2*extra + floatBound(real(value)) + floatBound(imag(value)) + len("(i)")
+}
@@ -0,0 +1,228 @@
+// =============================================================================
+// Project: tinyfmt
+// File: fixed_test.go
+// Description: Test suite and benchmarks for FixedBuffer in tinyfmt package.
+// Datasheet/Docs:
+//
+// Author: Jason Duffy
+// Created on: 18/10/2026
+//
+// Copyright: (C) 2026, Jason Duffy
+// License: See LICENSE file in the project root for full license information.
+// Disclaimer: See DISCLAIMER file in the project root for full disclaimer.
+// =============================================================================
+
+// -------------------------------------------------------------------------- //
+//                               Import Statement                             //
+// -------------------------------------------------------------------------- //
+
+package tinyfmt
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+// -------------------------------------------------------------------------- //
+//               Private Consts, Structs & Variable Definitions               //
+// -------------------------------------------------------------------------- //
+
+// scalarCases covers every verb for the scalar types. The arguments are boxed
+// here, once, so the allocation tests measure the formatting alone.
+var scalarCases = []struct {
+	format    string
+	arguments []interface{}
+	want      string
+}{
+	{"%d", []interface{}{-123456789}, "-123456789"},
+	{"%+08d", []interface{}{int16(-42)}, "-0000042"},
+	{"%x", []interface{}{uint32(0xdeadbeef)}, "0xdeadbeef"},
+	{"%X", []interface{}{uint64(0xbeef)}, "0XBEEF"},
+	{"%o", []interface{}{int64(511)}, "0o777"},
+	{"%b", []interface{}{uint8(5)}, "0b101"},
+	{"%c", []interface{}{'µ'}, "µ"},
+	{"%q", []interface{}{'\n'}, `'\n'`},
+	{"%U", []interface{}{0x1f600}, "U+1F600"},
+	{"%e", []interface{}{-1234.5678}, "-1.234568e+03"},
+	{"%E", []interface{}{float32(1e-7)}, "1.000000E-07"},
+	{"%.3f", []interface{}{3.14159}, "3.142"},
+	{"%F", []interface{}{2.5}, "2.500000"},
+	{"%g", []interface{}{6.02214076e23}, "6.02214076e+23"},
+	{"%G", []interface{}{1e-9}, "1E-09"},
+	{"%.2f", []interface{}{complex(1.5, -2)}, "(1.50-2.00i)"},
+	{"%s|%-6s|", []interface{}{"temp", "ok"}, "temp|ok    |"},
+	{"%s|%-6s|", []interface{}{[]byte("temp"), []byte("ok")}, "temp|ok    |"},
+	{"%q", []interface{}{"line\tbreak"}, `"line\tbreak"`},
+	{"%x", []interface{}{"\x01\xab"}, "01ab"},
+	{"%v %v %v", []interface{}{true, 42, 0.5}, "true 42 0.5"},
+	{"%*d", []interface{}{6, 42}, "    42"},
+	{"%[2]d-%[1]d", []interface{}{1, 2}, "2-1"},
+}
+
+// -------------------------------------------------------------------------- //
+//                              Public Functions                              //
+// -------------------------------------------------------------------------- //
+
+func TestFixedBuffer(t *testing.T) {
+	b := NewFixedBuffer(make([]byte, 16))
+	if b.Len() != 0 || b.Cap() != 16 {
+		t.Fatalf("NewFixedBuffer: Len() = %d, Cap() = %d, want 0 and 16", b.Len(), b.Cap())
+	}
+
+	// Test output that fits
+	if err := b.Printf("t=%d", 21); err != nil {
+		t.Fatalf("Printf unexpected error: %v", err)
+	}
+	b.Print(" ok", 1, 2)
+	if got := b.String(); got != "t=21 ok1 2" || b.Overflow() {
+		t.Errorf("FixedBuffer = %q (overflow %v), want %q without overflow", got, b.Overflow(), "t=21 ok1 2")
+	}
+
+	// Test a formatting error writes nothing
+	var formatErr *FormatError
+	if err := b.Printf("%d", "x"); !errors.As(err, &formatErr) || b.Len() != 10 {
+		t.Errorf("Printf with a bad argument = %v, Len() = %d, want a *FormatError and 10", err, b.Len())
+	}
+
+	// Test output is truncated at the capacity
+	b.Println("overflowing")
+	if got := b.String(); got != "t=21 ok1 2overfl" || !b.Overflow() {
+		t.Errorf("FixedBuffer = %q (overflow %v), want %q with overflow", got, b.Overflow(), "t=21 ok1 2overfl")
+	}
+
+	// Test Reset clears the output and the overflow flag
+	b.Reset()
+	if b.Len() != 0 || b.Overflow() || b.Cap() != 16 {
+		t.Errorf("After Reset: Len() = %d, Overflow() = %v, Cap() = %d", b.Len(), b.Overflow(), b.Cap())
+	}
+
+	// Test truncation does not split a rune
+	b.Print("temperature: 21°C")
+	if got := b.String(); got != "temperature: 21" || !b.Overflow() {
+		t.Errorf("FixedBuffer = %q (overflow %v), want %q with overflow", got, b.Overflow(), "temperature: 21")
+	}
+
+	// Test Write reports a short write
+	b.Reset()
+	n, err := Fprintf(b, "%s", "0123456789abcdefXYZ")
+	if n != 16 || err != io.ErrShortWrite || !b.Overflow() {
+		t.Errorf("Fprintf into a full FixedBuffer = (%d, %v), want (16, %v)", n, err, io.ErrShortWrite)
+	}
+}
+
+func TestFixedBufferScalarVerbs(t *testing.T) {
+	b := NewFixedBuffer(make([]byte, 64))
+	for _, testCase := range scalarCases {
+		b.Reset()
+		if err := b.Printf(testCase.format, testCase.arguments...); err != nil {
+			t.Errorf("Printf(%q, %v) returned error: %v", testCase.format, testCase.arguments, err)
+			continue
+		}
+		if got := b.String(); got != testCase.want {
+			t.Errorf("Printf(%q, %v) = %q, want %q", testCase.format, testCase.arguments, got, testCase.want)
+		}
+	}
+}
+
+func TestFixedBufferOverflow(t *testing.T) {
+	testCases := []struct {
+		format    string
+		arguments []interface{}
+		want      string
+	}{
+		{"%.3f|%d", []interface{}{3.14159, 123456}, "3.142|12"},   // Test a number cut short
+		{"%-12d|", []interface{}{42}, "42      "},                 // Test padding cut short
+		{"%q", []interface{}{"a\tb\x00c"}, `"a\tb\x0`},            // Test escapes cut short
+		{"%s %d", []interface{}{"123456", 789}, "123456 7"},       // Test later directives stop
+		{"%v", []interface{}{[]int{1, 2, 3, 4, 5}}, "[1 2 3 4"},   // Test a composite cut short
+		{"%x", []interface{}{"\x01\x02\x03\x04\x05"}, "01020304"}, // Test hex cut short
+		{"ab%5cd", []interface{}{'µ'}, "ab    µ"},                 // Test a rune is not split
+		{"%.2f", []interface{}{complex(1.5, -2)}, "(1.50-2."},     // Test a complex number cut short
+		{"%v|%v", []interface{}{true, 12345678}, "true|123"},      // Test a bool then an integer
+		{"%s", []interface{}{"overflowing text"}, "overflow"},     // Test a plain string cut short
+	}
+
+	storage := make([]byte, 8)
+	b := NewFixedBuffer(storage)
+	for _, testCase := range testCases {
+		b.Reset()
+		if err := b.Printf(testCase.format, testCase.arguments...); err != nil {
+			t.Errorf("Printf(%q, %v) returned error: %v", testCase.format, testCase.arguments, err)
+			continue
+		}
+		if got := b.String(); got != testCase.want || !b.Overflow() {
+			t.Errorf("Printf(%q, %v) = %q (overflow %v), want %q with overflow", testCase.format, testCase.arguments, got, b.Overflow(), testCase.want)
+		}
+		if got := b.Bytes(); cap(got) != cap(storage) || &got[:1][0] != &storage[0] {
+			t.Errorf("Printf(%q, %v) moved the output out of the storage", testCase.format, testCase.arguments)
+		}
+	}
+
+	// Test a bad directive after an overflow is still reported
+	b.Reset()
+	var formatErr *FormatError
+	if err := b.Printf("%s%d", "overflowing", "x"); !errors.As(err, &formatErr) || b.Len() != 0 {
+		t.Errorf("Printf with a bad argument after overflow = %v, Len() = %d, want a *FormatError and 0", err, b.Len())
+	}
+}
+
+func TestFixedBufferAllocs(t *testing.T) {
+	b := NewFixedBuffer(make([]byte, 8)) // Small, so most cases also overflow
+	for _, testCase := range scalarCases {
+		allocs := testing.AllocsPerRun(100, func() {
+			b.Reset()
+			b.Printf(testCase.format, testCase.arguments...)
+		})
+		if allocs != 0 {
+			t.Errorf("Printf(%q, %v) allocated %v times, want 0", testCase.format, testCase.arguments, allocs)
+		}
+	}
+
+	arguments := []interface{}{"id", 7, 2.5}
+	if allocs := testing.AllocsPerRun(100, func() { b.Reset(); b.Print(arguments...) }); allocs != 0 {
+		t.Errorf("Print allocated %v times, want 0", allocs)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { b.Reset(); b.Println(arguments...) }); allocs != 0 {
+		t.Errorf("Println allocated %v times, want 0", allocs)
+	}
+}
+
+func TestAppendfAllocs(t *testing.T) {
+	buf := make([]byte, 0, 64)
+	for _, testCase := range scalarCases {
+		allocs := testing.AllocsPerRun(100, func() {
+			Appendf(buf[:0], testCase.format, testCase.arguments...)
+		})
+		if allocs != 0 {
+			t.Errorf("Appendf(%q, %v) allocated %v times, want 0", testCase.format, testCase.arguments, allocs)
+		}
+	}
+}
+
+func BenchmarkFixedBufferPrintf(b *testing.B) {
+	buf := NewFixedBuffer(make([]byte, 64))
+	arguments := []interface{}{"adc0", 1023, 3.3}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		buf.Printf("%s=%04d (%.2fV)", arguments...)
+	}
+}
+
+func BenchmarkAppendf(b *testing.B) {
+	buf := make([]byte, 0, 64)
+	arguments := []interface{}{"adc0", 1023, 3.3}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf, _ = Appendf(buf[:0], "%s=%04d (%.2fV)", arguments...)
+	}
+}
+
+func BenchmarkSprintf(b *testing.B) {
+	arguments := []interface{}{"adc0", 1023, 3.3}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Sprintf("%s=%04d (%.2fV)", arguments...)
+	}
+}
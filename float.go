@@ -0,0 +1,627 @@
+// =============================================================================
+// Project: tinyfmt
+// File: float.go
+// Description: Float to decimal conversion for the %e, %f and %g verbs.
+// Datasheet/Docs: Steele & White, "How to Print Floating-Point Numbers
+//                 Accurately"; the shortest rounding follows Go's strconv.
+//
+// Author: Jason Duffy
+// Created on: 18/10/2026
+//
+// Copyright: (C) 2026, Jason Duffy
+// License: See LICENSE file in the project root for full license information.
+// Disclaimer: See DISCLAIMER file in the project root for full disclaimer.
+// =============================================================================
+
+// -------------------------------------------------------------------------- //
+//                               Import Statement                             //
+// -------------------------------------------------------------------------- //
+
+package tinyfmt
+
+import (
+	"math"
+)
+
+// -------------------------------------------------------------------------- //
+//               Private Consts, Structs & Variable Definitions               //
+// -------------------------------------------------------------------------- //
+
+// maxShift is the largest shift applied to a decimal in one pass, chosen so
+// that a digit shifted left still fits in a uint64.
+const maxShift = 60
+
+// shiftHeadroom is the number of digits in 2^maxShift, the most a single left
+// shift can add to a decimal.
+const shiftHeadroom = 19
+
+// floatInfo describes the layout of an IEEE 754 binary float.
+type floatInfo struct {
+	mantBits uint
+	expBits  uint
+	bias     int
+}
+
+var float32Info = floatInfo{mantBits: 23, expBits: 8, bias: -127}
+var float64Info = floatInfo{mantBits: 52, expBits: 11, bias: -1023}
+
+// smallDigits is the digit capacity of the decimals used when a float's exact
+// value is short enough, which covers float64 magnitudes from about 1e-23 to
+// 1e+106 and nearly every float32.
+const smallDigits = 128
+
+// largeDigits is the digit capacity of the decimals used for every other
+// float, enough for any float64.
+const largeDigits = 800
+
+// decimal is an exact, arbitrary precision decimal number. The value is
+// 0.d[0]d[1]...d[nd-1] * 10^dp.
+type decimal struct {
+	d     []byte // Digits, most significant first, in the caller's storage
+	nd    int    // Number of digits used
+	dp    int    // Position of the decimal point
+	trunc bool   // Nonzero digits were discarded beyond d[:nd]
+}
+
+// -------------------------------------------------------------------------- //
+//                          Private Helper Functions                          //
+// -------------------------------------------------------------------------- //
+
+// appendFloat appends a float in %e, %f or %g form (or their upper-case
+// variants). Without a precision, %e and %f use 6 places and %g uses the
+// fewest digits that read back as the same value at the given bit size.
+//
+// The digits are worked out in exact decimals on the stack, sized to the
+// value. Magnitudes from about 1e-23 to 1e+106 use 384 bytes of digits, about
+// 1.1kB of stack in all on amd64. Values beyond them use 2400 bytes in a
+// separate frame, about 3.1kB in all, which is the worst case whatever the
+// verb or precision.
+func appendFloat(dst []byte, value float64, bitSize int, verb byte, spec formatSpec) []byte {
+	flt := &float64Info
+	bits := math.Float64bits(value)
+	if bitSize == 32 {
+		flt = &float32Info
+		bits = uint64(math.Float32bits(float32(value)))
+	}
+	negative := bits>>(flt.expBits+flt.mantBits) != 0
+	exp := int(bits>>flt.mantBits) & (1<<flt.expBits - 1)
+	mant := bits & (1<<flt.mantBits - 1)
+
+	start := len(dst)
+	if negative {
+		dst = append(dst, '-')
+	} else {
+		dst = append(dst, '+')
+	}
+	if spec.space && !spec.plus && !negative {
+		dst[start] = ' '
+	}
+
+	// Infinities and NaN are never zero padded
+	if exp == 1<<flt.expBits-1 {
+		if mant != 0 {
+			// NaN has no sign, so the sign bit is ignored
+			dst = append(dst, "NaN"...)
+			switch {
+			case spec.plus:
+				dst[start] = '+'
+			case spec.space:
+				dst[start] = ' '
+			default:
+				dst = removeByte(dst, start)
+			}
+		} else {
+			dst = append(dst, "Inf"...)
+		}
+		spec.zero = false
+		return padFrom(dst, start, spec)
+	}
+
+	if exp == 0 {
+		exp++ // Denormalised
+	} else {
+		mant |= 1 << flt.mantBits
+	}
+	exp += flt.bias
+
+	if decimalDigits(flt.mantBits, exp-int(flt.mantBits)) > smallDigits {
+		return appendLargeFloat(dst, start, mant, exp, flt, verb, spec)
+	}
+	return appendSmallFloat(dst, start, mant, exp, flt, verb, spec)
+}
+
+// appendSmallFloat formats a float whose exact value fits in the small
+// decimals. Like appendLargeFloat it is kept out of line, so only one of
+// their storage arrays is ever on the stack.
+//
+//go:noinline
+func appendSmallFloat(dst []byte, start int, mant uint64, exp int, flt *floatInfo, verb byte, spec formatSpec) []byte {
+	// The value's digits, then room for the bounds of roundShortest
+	var storage [3 * smallDigits]byte
+	return appendDecimal(dst, start, storage[:], mant, exp, flt, verb, spec)
+}
+
+// appendLargeFloat formats a float too long for the small decimals.
+//
+//go:noinline
+func appendLargeFloat(dst []byte, start int, mant uint64, exp int, flt *floatInfo, verb byte, spec formatSpec) []byte {
+	var storage [3 * largeDigits]byte
+	return appendDecimal(dst, start, storage[:], mant, exp, flt, verb, spec)
+}
+
+// decimalDigits returns an upper bound on the digits needed to hold
+// mant * 2^shift exactly, along with the bounds of roundShortest and the
+// headroom of a left shift. 2^k has fewer than 0.302k + 1 digits and 5^k
+// fewer than 0.7k + 1.
+func decimalDigits(mantBits uint, shift int) int {
+	// The lower bound has two more mantissa bits and two more halvings
+	n := (int(mantBits)+3)*302/1000 + 1 + shiftHeadroom
+	if shift > 0 {
+		return n + shift*302/1000 + 1
+	}
+	return n + (2-shift)*700/1000 + 1
+}
+
+// appendDecimal formats mant * 2^(exp - mantBits) for appendFloat after the
+// sign at dst[start]. The first third of storage holds its digits and the
+// rest the bounds of roundShortest.
+func appendDecimal(dst []byte, start int, storage []byte, mant uint64, exp int, flt *floatInfo, verb byte, spec formatSpec) []byte {
+	size := len(storage) / 3
+	d := decimal{d: storage[:size]}
+	d.assign(mant)
+	d.shift(exp - int(flt.mantBits))
+
+	shortest := spec.precision < 0 && (verb == 'g' || verb == 'G')
+	precision := spec.precision
+	if shortest {
+		roundShortest(&d, mant, exp, flt, storage[size:])
+		precision = d.nd
+	} else {
+		if precision < 0 {
+			precision = 6
+		}
+		switch verb {
+		case 'e', 'E':
+			d.round(precision + 1)
+		case 'f', 'F':
+			d.round(d.dp + precision)
+		default:
+			if precision == 0 {
+				precision = 1
+			}
+			d.round(precision)
+		}
+	}
+
+	switch verb {
+	case 'e', 'E':
+		dst = appendExponent(dst, &d, precision, verb)
+	case 'f', 'F':
+		dst = appendFixed(dst, &d, precision)
+	default:
+		eprec := precision
+		if eprec > d.nd && d.nd >= d.dp {
+			eprec = d.nd
+		}
+		if shortest {
+			eprec = 6 // Matches the threshold of %g in C and Go
+		}
+		if x := d.dp - 1; x < -4 || x >= eprec {
+			if precision > d.nd {
+				precision = d.nd
+			}
+			dst = appendExponent(dst, &d, precision-1, verb+'e'-'g')
+		} else {
+			if precision > d.dp {
+				precision = d.nd
+			}
+			places := precision - d.dp
+			if places < 0 {
+				places = 0
+			}
+			dst = appendFixed(dst, &d, places)
+		}
+	}
+
+	if spec.sharp {
+		dst = appendAlternateFloat(dst, start, verb, spec.precision)
+	}
+
+	// Only show the sign when it was asked for or is negative
+	if !spec.plus && dst[start] == '+' {
+		dst = removeByte(dst, start)
+	} else if spec.zero && !spec.minus {
+		spec.width--
+		return padFrom(dst, start+1, spec)
+	}
+	return padFrom(dst, start, spec)
+}
+
+// appendExponent appends the digits of d as d.ddddde±dd.
+func appendExponent(dst []byte, d *decimal, precision int, verb byte) []byte {
+	first := byte('0')
+	if d.nd > 0 {
+		first = d.d[0]
+	}
+	dst = append(dst, first)
+
+	if precision > 0 {
+		dst = append(dst, '.')
+		i := 1
+		for ; i < d.nd && i <= precision; i++ {
+			dst = append(dst, d.d[i])
+		}
+		dst = appendPadding(dst, '0', precision+1-i)
+	}
+
+	dst = append(dst, verb)
+	exp := d.dp - 1
+	if d.nd == 0 {
+		exp = 0
+	}
+	if exp < 0 {
+		dst = append(dst, '-')
+		exp = -exp
+	} else {
+		dst = append(dst, '+')
+	}
+
+	// At least two exponent digits
+	switch {
+	case exp < 10:
+		dst = append(dst, '0', byte(exp)+'0')
+	case exp < 100:
+		dst = append(dst, byte(exp/10)+'0', byte(exp%10)+'0')
+	default:
+		dst = append(dst, byte(exp/100)+'0', byte(exp/10%10)+'0', byte(exp%10)+'0')
+	}
+	return dst
+}
+
+// appendFixed appends the digits of d as ddd.ddd with the given places.
+func appendFixed(dst []byte, d *decimal, places int) []byte {
+	if d.dp > 0 {
+		n := d.dp
+		if n > d.nd {
+			n = d.nd
+		}
+		dst = append(dst, d.d[:n]...)
+		dst = appendPadding(dst, '0', d.dp-n)
+	} else {
+		dst = append(dst, '0')
+	}
+
+	if places > 0 {
+		dst = append(dst, '.')
+		for i := 0; i < places; i++ {
+			digit := byte('0')
+			if j := d.dp + i; j >= 0 && j < d.nd {
+				digit = d.d[j]
+			}
+			dst = append(dst, digit)
+		}
+	}
+	return dst
+}
+
+// appendAlternateFloat applies the '#' flag to the number written since
+// start: it always has a decimal point, and %g keeps its trailing zeros.
+func appendAlternateFloat(dst []byte, start int, verb byte, precision int) []byte {
+	digits := 0
+	if verb == 'g' || verb == 'G' {
+		digits = precision
+		if digits < 0 {
+			digits = 6
+		}
+	}
+
+	// Set aside the exponent, if any
+	var tailBuffer [6]byte
+	tail := tailBuffer[:0]
+	hasPoint := false
+	sawNonzero := false
+	for i := start + 1; i < len(dst); i++ {
+		switch dst[i] {
+		case '.':
+			hasPoint = true
+		case 'e', 'E':
+			tail = append(tail, dst[i:]...)
+			dst = dst[:i]
+		default:
+			if dst[i] != '0' {
+				sawNonzero = true
+			}
+			if sawNonzero {
+				digits-- // Count significant digits
+			}
+		}
+	}
+
+	if !hasPoint {
+		if len(dst)-start == 2 && dst[start+1] == '0' {
+			digits-- // A lone zero counts as a digit
+		}
+		dst = append(dst, '.')
+	}
+	dst = appendPadding(dst, '0', digits)
+	return append(dst, tail...)
+}
+
+// removeByte removes dst[i], shifting the bytes after it down.
+func removeByte(dst []byte, i int) []byte {
+	copy(dst[i:], dst[i+1:])
+	return dst[:len(dst)-1]
+}
+
+// assign sets the decimal to an integer.
+func (a *decimal) assign(v uint64) {
+	var buffer [24]byte
+	n := 0
+	for v > 0 {
+		next := v / 10
+		buffer[n] = byte(v-next*10) + '0'
+		n++
+		v = next
+	}
+
+	a.nd = 0
+	for n--; n >= 0; n-- {
+		a.d[a.nd] = buffer[n]
+		a.nd++
+	}
+	a.dp = a.nd
+	a.trim()
+}
+
+// shift multiplies the decimal by 2^k, or divides it by 2^-k when k < 0.
+func (a *decimal) shift(k int) {
+	switch {
+	case a.nd == 0:
+		// Zero stays zero
+	case k > 0:
+		for k > maxShift {
+			a.leftShift(maxShift)
+			k -= maxShift
+		}
+		a.leftShift(uint(k))
+	case k < 0:
+		for k < -maxShift {
+			a.rightShift(maxShift)
+			k += maxShift
+		}
+		a.rightShift(uint(-k))
+	}
+}
+
+// leftShift multiplies the decimal by 2^k, working from the least significant
+// digit up so each digit is rewritten in place.
+func (a *decimal) leftShift(k uint) {
+	// Leave room in front for the carry digits
+	if a.nd+shiftHeadroom > len(a.d) {
+		for i := len(a.d) - shiftHeadroom; i < a.nd; i++ {
+			if a.d[i] != '0' {
+				a.trunc = true
+			}
+		}
+		a.nd = len(a.d) - shiftHeadroom
+	}
+	copy(a.d[shiftHeadroom:], a.d[:a.nd])
+
+	var carry uint64
+	for r := shiftHeadroom + a.nd - 1; r >= shiftHeadroom; r-- {
+		n := uint64(a.d[r]-'0')<<k + carry
+		carry = n / 10
+		a.d[r] = byte(n-carry*10) + '0'
+	}
+
+	w := shiftHeadroom
+	for carry > 0 {
+		next := carry / 10
+		w--
+		a.d[w] = byte(carry-next*10) + '0'
+		carry = next
+	}
+
+	added := shiftHeadroom - w
+	copy(a.d[:], a.d[w:shiftHeadroom+a.nd])
+	a.nd += added
+	a.dp += added
+	a.trim()
+}
+
+// rightShift divides the decimal by 2^k, working from the most significant
+// digit down.
+func (a *decimal) rightShift(k uint) {
+	r := 0 // Read index
+	w := 0 // Write index
+
+	// Pick up enough leading digits to produce the first output digit
+	var n uint64
+	for ; n>>k == 0; r++ {
+		if r >= a.nd {
+			if n == 0 {
+				a.nd = 0 // The value was zero
+				return
+			}
+			for n>>k == 0 {
+				n *= 10
+				r++
+			}
+			break
+		}
+		n = n*10 + uint64(a.d[r]-'0')
+	}
+	a.dp -= r - 1
+
+	mask := uint64(1)<<k - 1
+	for ; r < a.nd; r++ {
+		digit := n >> k
+		n &= mask
+		a.d[w] = byte(digit) + '0'
+		w++
+		n = n*10 + uint64(a.d[r]-'0')
+	}
+
+	// Write out the remainder
+	for n > 0 {
+		digit := n >> k
+		n &= mask
+		if w < len(a.d) {
+			a.d[w] = byte(digit) + '0'
+			w++
+		} else if digit > 0 {
+			a.trunc = true
+		}
+		n *= 10
+	}
+
+	a.nd = w
+	a.trim()
+}
+
+// trim drops trailing zeros.
+func (a *decimal) trim() {
+	for a.nd > 0 && a.d[a.nd-1] == '0' {
+		a.nd--
+	}
+	if a.nd == 0 {
+		a.dp = 0
+	}
+}
+
+// shouldRoundUp reports whether rounding to nd digits should round up,
+// rounding exact halves to even.
+func (a *decimal) shouldRoundUp(nd int) bool {
+	if a.d[nd] == '5' && nd+1 == a.nd {
+		if a.trunc {
+			return true // Slightly above the half
+		}
+		return nd > 0 && (a.d[nd-1]-'0')%2 == 1
+	}
+	return a.d[nd] >= '5'
+}
+
+// round rounds the decimal to nd digits.
+func (a *decimal) round(nd int) {
+	if nd < 0 || nd >= a.nd {
+		return
+	}
+	if a.shouldRoundUp(nd) {
+		a.roundUp(nd)
+	} else {
+		a.roundDown(nd)
+	}
+}
+
+// roundDown truncates the decimal to nd digits.
+func (a *decimal) roundDown(nd int) {
+	if nd < 0 || nd >= a.nd {
+		return
+	}
+	a.nd = nd
+	a.trim()
+}
+
+// roundUp rounds the decimal up to nd digits.
+func (a *decimal) roundUp(nd int) {
+	if nd < 0 || nd >= a.nd {
+		return
+	}
+	for i := nd - 1; i >= 0; i-- {
+		if a.d[i] < '9' {
+			a.d[i]++
+			a.nd = i + 1
+			return
+		}
+	}
+
+	// Every digit was a 9
+	a.d[0] = '1'
+	a.nd = 1
+	a.dp++
+}
+
+// roundShortest rounds d, the exact value of mant * 2^(exp - mantBits), to the
+// fewest digits that still lie strictly between the neighbouring floats, so
+// the result reads back as the same value. The two halves of storage hold
+// the digits of the halfway points.
+func roundShortest(d *decimal, mant uint64, exp int, flt *floatInfo, storage []byte) {
+	if mant == 0 {
+		d.nd = 0
+		return
+	}
+
+	// Integers with few enough digits are already as short as possible
+	minExp := flt.bias + 1
+	if exp > minExp && 332*(d.dp-d.nd) >= 100*(exp-int(flt.mantBits)) {
+		return
+	}
+
+	// The halfway points to the float above and below
+	upper := decimal{d: storage[:len(storage)/2]}
+	upper.assign(mant*2 + 1)
+	upper.shift(exp - int(flt.mantBits) - 1)
+
+	var mantLow uint64
+	var expLow int
+	if mant > 1<<flt.mantBits || exp == minExp {
+		mantLow = mant - 1
+		expLow = exp
+	} else {
+		mantLow = mant*2 - 1
+		expLow = exp - 1
+	}
+	lower := decimal{d: storage[len(storage)/2:]}
+	lower.assign(mantLow*2 + 1)
+	lower.shift(expLow - int(flt.mantBits) - 1)
+
+	// Halfway points round to even, so they are allowed when mant is even
+	inclusive := mant%2 == 0
+
+	// Walk the digits until the bounds differ enough to round
+	var upperDelta uint8
+	for ui := 0; ; ui++ {
+		mi := ui - upper.dp + d.dp
+		if mi >= d.nd {
+			break
+		}
+		li := ui - upper.dp + lower.dp
+
+		l := byte('0')
+		if li >= 0 && li < lower.nd {
+			l = lower.d[li]
+		}
+		m := byte('0')
+		if mi >= 0 {
+			m = d.d[mi]
+		}
+		u := byte('0')
+		if ui < upper.nd {
+			u = upper.d[ui]
+		}
+
+		okDown := l != m || inclusive && li+1 == lower.nd
+
+		switch {
+		case upperDelta == 0 && m+1 < u:
+			upperDelta = 2
+		case upperDelta == 0 && m != u:
+			upperDelta = 1
+		case upperDelta == 1 && (m != '9' || u != '0'):
+			upperDelta = 2
+		}
+		okUp := upperDelta > 0 && (inclusive || upperDelta > 1 || ui+1 < upper.nd)
+
+		switch {
+		case okDown && okUp:
+			d.round(mi + 1)
+			return
+		case okDown:
+			d.roundDown(mi + 1)
+			return
+		case okUp:
+			d.roundUp(mi + 1)
+			return
+		}
+	}
+}
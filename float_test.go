@@ -0,0 +1,166 @@
+// =============================================================================
+// Project: tinyfmt
+// File: float_test.go
+// Description: Test suite for float conversion in tinyfmt package.
+// Datasheet/Docs:
+//
+// Author: Jason Duffy
+// Created on: 18/10/2026
+//
+// Copyright: (C) 2026, Jason Duffy
+// License: See LICENSE file in the project root for full license information.
+// Disclaimer: See DISCLAIMER file in the project root for full disclaimer.
+// =============================================================================
+
+// -------------------------------------------------------------------------- //
+//                               Import Statement                             //
+// -------------------------------------------------------------------------- //
+
+package tinyfmt
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+// -------------------------------------------------------------------------- //
+//                              Public Functions                              //
+// -------------------------------------------------------------------------- //
+
+func TestSprintfFloatVerbs(t *testing.T) {
+	testCases := []struct {
+		format    string
+		arguments []interface{}
+		want      string
+	}{
+		{"%e", []interface{}{1234.5678}, "1.234568e+03"},                      // Test scientific notation
+		{"%E", []interface{}{-0.000123}, "-1.230000E-04"},                     // Test upper-case scientific notation
+		{"%.2e", []interface{}{6.02214076e23}, "6.02e+23"},                    // Test scientific notation with precision
+		{"%.0e", []interface{}{5.5}, "6e+00"},                                 // Test scientific notation with precision zero
+		{"%e", []interface{}{1e100}, "1.000000e+100"},                         // Test three digit exponent
+		{"%g", []interface{}{3.14159}, "3.14159"},                             // Test shortest representation
+		{"%g", []interface{}{1e6}, "1e+06"},                                   // Test large exponent switches to %e
+		{"%g", []interface{}{123456.0}, "123456"},                             // Test six digits stays fixed
+		{"%g", []interface{}{0.0001}, "0.0001"},                               // Test small exponent stays fixed
+		{"%g", []interface{}{0.00001}, "1e-05"},                               // Test smaller exponent switches to %e
+		{"%G", []interface{}{1e-10}, "1E-10"},                                 // Test upper-case shortest representation
+		{"%.3g", []interface{}{3.14159}, "3.14"},                              // Test significant digits
+		{"%.3g", []interface{}{1234567.0}, "1.23e+06"},                        // Test significant digits with exponent
+		{"%f", []interface{}{3.14159}, "3.141590"},                            // Test default precision of six
+		{"%.1f", []interface{}{0.25}, "0.2"},                                  // Test round half to even
+		{"%.2f", []interface{}{2.675}, "2.67"},                                // Test rounding of the exact binary value
+		{"%.0f", []interface{}{0.5}, "0"},                                     // Test round half to even at zero places
+		{"%F", []interface{}{1.5}, "1.500000"},                                // Test %F alias
+		{"%v", []interface{}{0.30000000000000004}, "0.30000000000000004"},     // Test shortest round-trip
+		{"%v", []interface{}{float32(0.1)}, "0.1"},                            // Test float32 shortest round-trip
+		{"%v", []interface{}{float32(16777216.0)}, "1.6777216e+07"},           // Test large float32
+		{"%v", []interface{}{1e21}, "1e+21"},                                  // Test large float64
+		{"%v", []interface{}{5e-324}, "5e-324"},                               // Test smallest denormal
+		{"%v", []interface{}{math.MaxFloat64}, "1.7976931348623157e+308"},     // Test largest float64
+		{"%v", []interface{}{-0.0}, "0"},                                      // Test zero
+		{"%v", []interface{}{math.Copysign(0, -1)}, "-0"},                     // Test negative zero
+		{"%v", []interface{}{math.Inf(1)}, "+Inf"},                            // Test positive infinity
+		{"%v", []interface{}{math.Inf(-1)}, "-Inf"},                           // Test negative infinity
+		{"%v", []interface{}{math.NaN()}, "NaN"},                              // Test NaN
+		{"%+g", []interface{}{math.NaN()}, "+NaN"},                            // Test NaN with plus flag
+		{"%+v", []interface{}{math.NaN()}, "NaN"},                             // Test plus selects field names for %v, not a sign
+		{"%v", []interface{}{math.Copysign(math.NaN(), -1)}, "NaN"},           // Test NaN with the sign bit set
+		{"%+08.3f", []interface{}{math.Copysign(math.NaN(), -1)}, "    +NaN"}, // Test signed NaN with plus flag
+		{"% e", []interface{}{math.Copysign(math.NaN(), -1)}, " NaN"},         // Test signed NaN with space flag
+		{"%06v", []interface{}{math.Inf(-1)}, "  -Inf"},                       // Test infinity is not zero padded
+		{"%10.3e|", []interface{}{-1.5}, "-1.500e+00|"},                       // Test width with scientific notation
+		{"%012.3e", []interface{}{-1.5}, "-001.500e+00"},                      // Test zero padded scientific notation
+		{"% g", []interface{}{2.5}, " 2.5"},                                   // Test space flag
+		{"%#g", []interface{}{1.0}, "1.00000"},                                // Test alternate %g keeps zeros
+		{"%#.0f", []interface{}{3.0}, "3."},                                   // Test alternate %f keeps the point
+		{"%#.0e", []interface{}{3.0}, "3.e+00"},                               // Test alternate %e keeps the point
+		{"%6.2v", []interface{}{3.14159}, "   3.1"},                           // Test %v with precision
+		{"%e", []interface{}{complex(1, -2)}, "(1.000000e+00-2.000000e+00i)"}, // Test complex scientific notation
+		{"%g", []interface{}{complex64(1.5 + 0.5i)}, "(1.5+0.5i)"},            // Test complex shortest representation
+	}
+
+	for _, testCase := range testCases {
+		got, err := Sprintf(testCase.format, testCase.arguments...)
+		if err != nil {
+			t.Errorf("Sprintf(%q, %v) unexpected error: %v", testCase.format, testCase.arguments, err)
+			continue
+		}
+		if got != testCase.want {
+			t.Errorf("Sprintf(%q, %v) = %q, want %q", testCase.format, testCase.arguments, got, testCase.want)
+		}
+	}
+}
+
+// Cross-check the conversion against strconv over random bit patterns
+func TestAppendFloatMatchesStrconv(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	verbs := []byte{'e', 'f', 'g'}
+	precisions := []int{-1, 0, 1, 3, 6, 17}
+
+	for i := 0; i < 5000; i++ {
+		value := math.Float64frombits(random.Uint64())
+		bitSize := 64
+		if i%2 == 1 {
+			value = float64(math.Float32frombits(random.Uint32()))
+			bitSize = 32
+		}
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			continue
+		}
+		for _, verb := range verbs {
+			for _, precision := range precisions {
+				if verb == 'f' && math.Abs(value) > 1e30 && precision > 3 {
+					continue // Keep the fixed-point strings short
+				}
+				if precision < 0 && verb != 'g' {
+					continue
+				}
+				spec := formatSpec{width: -1, precision: precision}
+				got := string(appendFloat(nil, value, bitSize, verb, spec))
+				want := strconv.FormatFloat(value, verb, precision, bitSize)
+				if got != want {
+					t.Fatalf("appendFloat(%v, %d, %c, %d) = %q, want %q", value, bitSize, verb, precision, got, want)
+				}
+			}
+		}
+	}
+}
+
+// Cross-check every exponent, so both the small and the large decimals are
+// used, including on either side of the limit between them
+func TestAppendFloatEveryExponent(t *testing.T) {
+	random := rand.New(rand.NewSource(2))
+	verbs := []byte{'e', 'f', 'g'}
+	precisions := []int{-1, 0, 6, 17}
+
+	for _, bitSize := range []int{32, 64} {
+		flt := &float64Info
+		if bitSize == 32 {
+			flt = &float32Info
+		}
+		for exp := uint64(0); exp < 1<<flt.expBits-1; exp++ {
+			for i := 0; i < 3; i++ {
+				bits := exp<<flt.mantBits | random.Uint64()&(1<<flt.mantBits-1)
+				value := math.Float64frombits(bits)
+				if bitSize == 32 {
+					value = float64(math.Float32frombits(uint32(bits)))
+				}
+				for _, verb := range verbs {
+					for _, precision := range precisions {
+						if precision < 0 && verb != 'g' {
+							continue
+						}
+						spec := formatSpec{width: -1, precision: precision}
+						got := string(appendFloat(nil, value, bitSize, verb, spec))
+						want := strconv.FormatFloat(value, verb, precision, bitSize)
+						if got != want {
+							t.Fatalf("appendFloat(%v, %d, %c, %d) = %q, want %q", value, bitSize, verb, precision, got, want)
+						}
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,860 @@
+// =============================================================================
+// Project: tinyfmt
+// File: format.go
+// Description: The directive engine and padding helpers behind Sprintf.
+// Datasheet/Docs:
+//
+// Author: Jason Duffy
+// Created on: 18/10/2026
+//
+// Copyright: (C) 2026, Jason Duffy
+// License: See LICENSE file in the project root for full license information.
+// Disclaimer: See DISCLAIMER file in the project root for full disclaimer.
+// =============================================================================
+
+// -------------------------------------------------------------------------- //
+//                               Import Statement                             //
+// -------------------------------------------------------------------------- //
+
+package tinyfmt
+
+import (
+	"sync"
+	"unicode/utf8"
+
+	"github.com/Jason-Duffy/tinystrconv"
+)
+
+// -------------------------------------------------------------------------- //
+//               Private Consts, Structs & Variable Definitions               //
+// -------------------------------------------------------------------------- //
+
+const lowerDigits = "0123456789abcdef"
+const upperDigits = "0123456789ABCDEF"
+
+// formatSpec holds the flags, width and precision of a single directive.
+type formatSpec struct {
+	minus     bool // '-': pad with spaces on the right
+	plus      bool // '+': always print a sign for numeric values
+	space     bool // ' ': leave a space for an elided sign
+	zero      bool // '0': pad with leading zeros after the sign
+	sharp     bool // '#': alternate format; integers are always prefixed, so it is ignored for them
+	width     int  // Minimum width, -1 when absent
+	precision int  // Precision, -1 when absent
+}
+
+// maxWidth bounds widths and precisions, so a stray number cannot make the
+// output enormous.
+const maxWidth = 1000000
+
+// directive is a parsed %-directive. Argument indexes are written [n] in the
+// format and counted from 1; zero means there is no index.
+type directive struct {
+	spec           formatSpec
+	widthIndex     int  // Index before the width
+	widthStar      bool // Width taken from an argument ('*')
+	precisionIndex int  // Index before the precision
+	precisionStar  bool // Precision taken from an argument ('*')
+	verbIndex      int  // Index before the verb
+	badIndex       bool // An index was malformed or misplaced
+	verb           rune // The verb, 0 if the format ended first
+}
+
+// printer holds the state of a single formatting call.
+type printer struct {
+	buf       []byte  // Formatted output
+	wrapErrs  bool    // Whether %w is allowed, as it is in Errorf
+	wrapped   []error // Operands of %w, in order
+	lenient   bool    // Whether bad directives are written inline instead of failing
+	argNum    int     // Index of the next argument
+	reordered bool    // Whether an explicit argument index was used
+	fixed     bool    // Whether buf is a FixedBuffer's storage, which must not grow
+	overflow  bool    // Whether a fixed printer has cut its output short
+}
+
+// maxPooledBuffer bounds the buffers kept in printerPool, so one huge output
+// does not pin its memory for the life of the program.
+const maxPooledBuffer = 64 << 10
+
+// printerPool recycles printers, so the buffers of Sprintf and friends are
+// reused instead of allocated on every call.
+var printerPool = sync.Pool{
+	New: func() interface{} { return new(printer) },
+}
+
+// defaultSpec is the spec of a bare directive with no flags, width or precision.
+var defaultSpec = formatSpec{width: -1, precision: -1}
+
+// -------------------------------------------------------------------------- //
+//                          Private Helper Functions                          //
+// -------------------------------------------------------------------------- //
+
+// newPrinter takes a printer from the pool, with an empty buffer.
+func newPrinter() *printer {
+	return printerPool.Get().(*printer)
+}
+
+// free resets the printer and returns it to the pool. Its buffer must not be
+// used afterwards.
+func (p *printer) free() {
+	if cap(p.buf) > maxPooledBuffer {
+		return
+	}
+	*p = printer{buf: p.buf[:0]}
+	printerPool.Put(p)
+}
+
+// print appends the arguments in their default format, adding a space
+// between operands when neither is a string.
+func (p *printer) print(arguments []interface{}) {
+	prevString := false
+	for i, argument := range arguments {
+		isString := isStringKind(argument)
+		if i > 0 && !isString && !prevString {
+			p.writeByte(' ')
+		}
+		p.writeArg(argument, 'v', defaultSpec)
+		prevString = isString
+	}
+}
+
+// println appends the arguments in their default format, always separated
+// by spaces and followed by a newline.
+func (p *printer) println(arguments []interface{}) {
+	for i, argument := range arguments {
+		if i > 0 {
+			p.writeByte(' ')
+		}
+		p.writeArg(argument, 'v', defaultSpec)
+	}
+	p.writeByte('\n')
+}
+
+// printf appends the arguments formatted according to the format specifier
+// to p.buf. It stops at the first bad directive, returning a *FormatError,
+// unless the printer is lenient, in which case the directive is replaced with
+// a marker and formatting continues.
+func (p *printer) printf(format string, arguments []interface{}) error {
+	p.argNum = 0
+	p.reordered = false
+
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			end := i + 1
+			for end < len(format) && format[end] != '%' {
+				end++
+			}
+			p.writeString(format[i:end])
+			i = end - 1
+			continue
+		}
+
+		// Handle flags, width, precision and indexes (e.g. "%-[2]*.2f")
+		d, next := parseDirective(format, i+1)
+		if err := p.printDirective(&d, i, arguments); err != nil {
+			return err
+		}
+		i = next
+	}
+
+	return p.checkExtra(len(format), arguments)
+}
+
+// printDirective formats the arguments for one directive, whose '%' is at
+// offset in the format string.
+func (p *printer) printDirective(d *directive, offset int, arguments []interface{}) error {
+	spec := d.spec
+	goodIndex := !d.badIndex
+	if d.badIndex {
+		p.reordered = true
+	}
+
+	if d.widthIndex != 0 {
+		goodIndex = p.moveToArg(d.widthIndex, len(arguments)) && goodIndex
+	}
+	if d.widthStar {
+		argNum := p.argNum
+		width, ok := p.intArg(arguments)
+		if !ok {
+			if err := p.fail(BadWidth, offset, 0, argNum, arguments); err != nil {
+				return err
+			}
+		} else if width < 0 {
+			spec.minus = true // A negative width pads on the right
+			spec.zero = false
+			spec.width = -width
+		} else {
+			spec.width = width
+		}
+	}
+
+	if d.precisionIndex != 0 {
+		goodIndex = p.moveToArg(d.precisionIndex, len(arguments)) && goodIndex
+	}
+	if d.precisionStar {
+		argNum := p.argNum
+		precision, ok := p.intArg(arguments)
+		if !ok {
+			if err := p.fail(BadPrecision, offset, 0, argNum, arguments); err != nil {
+				return err
+			}
+		} else if precision < 0 {
+			// A negative precision makes no sense, so it is reported
+			if err := p.fail(BadPrecision, offset, 0, argNum, arguments); err != nil {
+				return err
+			}
+		} else {
+			spec.precision = precision
+		}
+	}
+
+	if d.verbIndex != 0 {
+		goodIndex = p.moveToArg(d.verbIndex, len(arguments)) && goodIndex
+	}
+
+	switch {
+	case d.verb == 0:
+		return p.fail(IncompleteDirective, offset, 0, -1, arguments)
+	case d.verb == '%':
+		p.writeByte('%')
+	case !goodIndex:
+		return p.fail(BadArgumentIndex, offset, d.verb, -1, arguments)
+	case p.argNum >= len(arguments):
+		return p.fail(MissingArgument, offset, d.verb, p.argNum, arguments)
+	default:
+		kind := p.writeArg(arguments[p.argNum], d.verb, spec)
+		p.argNum++
+		if kind != formatOK {
+			return p.fail(kind, offset, d.verb, p.argNum-1, arguments)
+		}
+	}
+	return nil
+}
+
+// checkExtra reports arguments left over at the end of the format string,
+// which usually mean a directive is missing. It is skipped once explicit
+// indexes have been used, as they need not consume every argument in order.
+func (p *printer) checkExtra(offset int, arguments []interface{}) error {
+	if p.reordered || p.argNum >= len(arguments) {
+		return nil
+	}
+	if !p.lenient {
+		return newFormatError(ExtraArguments, offset, 0, p.argNum, arguments[p.argNum])
+	}
+
+	p.writeString("%!(EXTRA ")
+	for i, argument := range arguments[p.argNum:] {
+		if i > 0 {
+			p.writeString(", ")
+		}
+		p.appendTypedValue(argument)
+	}
+	p.writeByte(')')
+	return nil
+}
+
+// fail reports a bad directive. A strict printer returns a *FormatError; a
+// lenient one writes a marker in its place, such as %!d(MISSING), and
+// returns nil.
+func (p *printer) fail(kind FormatErrorKind, offset int, verb rune, argIndex int, arguments []interface{}) error {
+	var argument interface{}
+	if argIndex >= len(arguments) && kind != MissingArgument {
+		argIndex = -1 // A '*' ran out of arguments
+	}
+	if argIndex >= 0 && argIndex < len(arguments) {
+		argument = arguments[argIndex]
+	}
+	if !p.lenient {
+		return newFormatError(kind, offset, verb, argIndex, argument)
+	}
+
+	switch kind {
+	case IncompleteDirective:
+		p.writeString("%!(NOVERB)")
+	case BadWidth:
+		p.writeString("%!(BADWIDTH)")
+	case BadPrecision:
+		p.writeString("%!(BADPREC)")
+	case MissingArgument:
+		p.writeString("%!" + string(verb) + "(MISSING)")
+	case BadArgumentIndex:
+		p.writeString("%!" + string(verb) + "(BADINDEX)")
+	default:
+		p.writeString("%!" + string(verb) + "(")
+		p.appendTypedValue(argument)
+		p.writeByte(')')
+	}
+	return nil
+}
+
+// moveToArg makes the argument with the given index (counted from 1) the
+// next one, reporting whether the index is in range.
+func (p *printer) moveToArg(index int, numArgs int) bool {
+	p.reordered = true
+	if index < 1 || index > numArgs {
+		return false
+	}
+	p.argNum = index - 1
+	return true
+}
+
+// intArg consumes the next argument as a width or precision, reporting
+// whether it is an integer of a sensible size.
+func (p *printer) intArg(arguments []interface{}) (int, bool) {
+	if p.argNum >= len(arguments) {
+		return 0, false
+	}
+	magnitude, negative, ok := integerArgument(arguments[p.argNum])
+	p.argNum++
+	if !ok || magnitude > maxWidth {
+		return 0, false
+	}
+	if negative {
+		return -int(magnitude), true
+	}
+	return int(magnitude), true
+}
+
+// appendTypedValue appends an argument as type=value for the inline markers
+// of a lenient printer, or <nil> for nil.
+func (p *printer) appendTypedValue(argument interface{}) {
+	if argument == nil {
+		p.writeString("<nil>")
+		return
+	}
+	p.writeString(typeName(argument))
+	p.writeByte('=')
+	p.writeArg(argument, 'v', defaultSpec)
+}
+
+// writeString appends text such as format literals, separators and markers.
+// A fixed printer writes only what fits.
+func (p *printer) writeString(str string) {
+	if p.fixed {
+		str = str[:fixedCut(p, str)]
+	}
+	p.buf = append(p.buf, str...)
+}
+
+// writeByte appends a single ASCII byte. A fixed printer drops it if the
+// buffer is full.
+func (p *printer) writeByte(c byte) {
+	if p.fixed && (p.overflow || len(p.buf) == cap(p.buf)) {
+		p.overflow = true
+		return
+	}
+	p.buf = append(p.buf, c)
+}
+
+// printArg appends a single argument formatted for the verb. It returns the
+// kind of error when the verb is unknown or does not suit the argument.
+func (p *printer) printArg(argument interface{}, verb rune, spec formatSpec) FormatErrorKind {
+	// Types that format themselves handle every verb but %w and %p
+	if formatter, ok := argument.(Formatter); ok && verb != 'w' && verb != 'p' {
+		p.buf = appendFormatter(p.buf, formatter, verb, spec)
+		return formatOK
+	}
+	if verb >= utf8.RuneSelf {
+		return BadVerb // All the other verbs are ASCII
+	}
+
+	// Scalars are shared with FixedBuffer, which formats them on the stack
+	if dst, ok := appendScalar(p.buf, argument, byte(verb), spec); ok {
+		p.buf = dst
+		return formatOK
+	}
+
+	// Handle different format specifiers
+	switch verb {
+	case 'd', 'b', 'x', 'X', 'o', 'e', 'E', 'f', 'F', 'g', 'G', 'q', 'c', 'U':
+		return WrongArgumentType
+	case 'v':
+		p.buf = appendValue(p.buf, argument, spec)
+	case 's':
+		if strVal, ok := handleMethods(argument, 's'); ok {
+			p.buf = appendString(p.buf, strVal, spec)
+		} else {
+			return WrongArgumentType
+		}
+	case 'p':
+		address, ok := pointerArgument(argument)
+		if !ok {
+			return WrongArgumentType
+		}
+		p.buf = appendPointer(p.buf, address, spec)
+	case 'w':
+		if !p.wrapErrs {
+			return BadVerb
+		}
+		wrapped, ok := argument.(error)
+		if !ok {
+			return WrongArgumentType
+		}
+		p.wrapped = append(p.wrapped, wrapped)
+		p.buf = appendValue(p.buf, wrapped, spec)
+	default:
+		return BadVerb
+	}
+	return formatOK
+}
+
+// appendScalar appends a number, rune, bool, nil, string or byte slice
+// formatted for the verb, reporting false if the argument is none of those or
+// the verb does not suit it. It calls nothing that can keep dst, so dst may
+// live on the stack. %v takes only the plain form, as its '+' and '#' flags
+// are handled by appendValue.
+func appendScalar(dst []byte, argument interface{}, verb byte, spec formatSpec) ([]byte, bool) {
+	if verb == 'v' {
+		if spec.plus || spec.sharp {
+			return dst, false
+		}
+		switch value := argument.(type) {
+		case nil:
+			return appendWhole(dst, "<nil>", spec), true
+		case bool:
+			return appendWhole(dst, tinystrconv.BoolToString(value), spec), true
+		case string:
+			return appendString(dst, value, spec), true
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr:
+			magnitude, negative, _ := integerArgument(value)
+			return appendInteger(dst, magnitude, negative, 'd', spec), true
+		case float32:
+			return appendFloat(dst, float64(value), 32, 'g', spec), true
+		case float64:
+			return appendFloat(dst, value, 64, 'g', spec), true
+		case complex64:
+			return appendComplex(dst, complex128(value), 32, 'g', spec), true
+		case complex128:
+			return appendComplex(dst, value, 64, 'g', spec), true
+		}
+		return dst, false
+	}
+
+	if magnitude, negative, ok := integerArgument(argument); ok {
+		switch verb {
+		case 'd', 'b', 'x', 'X', 'o':
+			return appendInteger(dst, magnitude, negative, verb, spec), true
+		case 'q':
+			return appendQuotedRune(dst, runeArgument(magnitude, negative), spec), true
+		case 'c':
+			return appendRune(dst, runeArgument(magnitude, negative), spec), true
+		case 'U':
+			if negative {
+				magnitude = -magnitude // Two's complement, as fmt prints it
+			}
+			return appendUnicode(dst, magnitude, spec), true
+		}
+		return dst, false
+	}
+
+	switch verb {
+	case 'e', 'E', 'f', 'F', 'g', 'G':
+		if floatVal, bitSize, ok := floatArgument(argument); ok {
+			return appendFloat(dst, floatVal, bitSize, verb, spec), true
+		}
+		if complexVal, bitSize, ok := complexArgument(argument); ok {
+			return appendComplex(dst, complexVal, bitSize, verb, spec), true
+		}
+	case 's':
+		switch value := argument.(type) {
+		case string:
+			return appendString(dst, value, spec), true
+		case []byte:
+			return appendString(dst, string(value), spec), true
+		}
+	case 'q':
+		switch value := argument.(type) {
+		case string:
+			return appendQuotedString(dst, value, spec), true
+		case []byte:
+			return appendQuotedString(dst, string(value), spec), true
+		}
+	case 'x', 'X':
+		switch value := argument.(type) {
+		case string:
+			return appendHex(dst, value, nil, verb, spec), true
+		case []byte:
+			return appendHex(dst, "", value, verb, spec), true
+		}
+	}
+	return dst, false
+}
+
+// parseDirective parses the directive after a '%' starting at format[i],
+// returning it and the index of the last byte of its verb, which may be any
+// rune. The grammar matches fmt:
+// flags, then an optional [n] index and width (digits or '*'), then '.', an
+// optional [n] index and precision (digits or '*'), then an optional [n]
+// index and the verb.
+func parseDirective(format string, i int) (directive, int) {
+	d := directive{spec: defaultSpec}
+
+	// Flags may appear in any order and may repeat
+	for ; i < len(format); i++ {
+		switch format[i] {
+		case '-':
+			d.spec.minus = true
+			d.spec.zero = false // '-' overrides '0'
+			continue
+		case '+':
+			d.spec.plus = true
+			continue
+		case ' ':
+			d.spec.space = true
+			continue
+		case '0':
+			d.spec.zero = !d.spec.minus
+			continue
+		case '#':
+			d.spec.sharp = true
+			continue
+		}
+		break
+	}
+
+	// Handle the width (e.g. "%8d", "%*d" or "%[2]*d")
+	var afterIndex bool
+	d.widthIndex, i, afterIndex = parseIndex(format, i, &d.badIndex)
+	if i < len(format) && format[i] == '*' {
+		d.widthStar = true
+		afterIndex = false
+		i++
+	} else {
+		d.spec.width, i = parseNumber(format, i)
+		if afterIndex && d.spec.width >= 0 {
+			d.badIndex = true // An index must be followed by '*' or the verb
+		}
+	}
+
+	// Handle the precision (e.g. "%.2f"); a lone '.' means precision zero,
+	// unless it ends the format, in which case it is the verb
+	if i+1 < len(format) && format[i] == '.' {
+		if afterIndex {
+			d.badIndex = true
+		}
+		d.precisionIndex, i, afterIndex = parseIndex(format, i+1, &d.badIndex)
+		if i < len(format) && format[i] == '*' {
+			d.precisionStar = true
+			afterIndex = false
+			i++
+		} else {
+			d.spec.precision, i = parseNumber(format, i)
+			if d.spec.precision < 0 {
+				d.spec.precision = 0
+			}
+		}
+	}
+
+	if !afterIndex {
+		d.verbIndex, i, _ = parseIndex(format, i, &d.badIndex)
+	}
+	if i < len(format) {
+		var size int
+		d.verb, size = utf8.DecodeRuneInString(format[i:])
+		i += size - 1
+	}
+	return d, i
+}
+
+// parseIndex parses an argument index of the form [n] at format[i],
+// returning n (zero if there is none), the index after it and whether a
+// well-formed one was found. A malformed index sets *bad and, as in fmt,
+// counts as no index, so another may follow it.
+func parseIndex(format string, i int, bad *bool) (int, int, bool) {
+	if i >= len(format) || format[i] != '[' {
+		return 0, i, false
+	}
+	for j := i + 1; j < len(format); j++ {
+		if format[j] == ']' {
+			index, end := parseNumber(format, i+1)
+			if index < 0 || end != j {
+				*bad = true
+				return 0, j + 1, false
+			}
+			if index == 0 {
+				*bad = true // Indexes count from 1
+			}
+			return index, j + 1, true
+		}
+	}
+	*bad = true // No closing bracket, so only the '[' is skipped
+	return 0, i + 1, false
+}
+
+// parseNumber parses a run of decimal digits starting at format[i], returning
+// the value (or -1 if there are none or it exceeds maxWidth) and the index
+// after the last digit.
+func parseNumber(format string, i int) (int, int) {
+	number := -1
+	for ; i < len(format) && format[i] >= '0' && format[i] <= '9'; i++ {
+		if number < 0 {
+			number = 0
+		}
+		if number <= maxWidth {
+			number = number*10 + int(format[i]-'0')
+		}
+	}
+	if number > maxWidth {
+		number = -1
+	}
+	return number, i
+}
+
+// appendPadding appends n copies of the padding byte.
+func appendPadding(dst []byte, padByte byte, n int) []byte {
+	for ; n > 0; n-- {
+		dst = append(dst, padByte)
+	}
+	return dst
+}
+
+// padFrom pads the text written to dst since start out to the spec's width,
+// on the left or right depending on the '-' flag.
+func padFrom(dst []byte, start int, spec formatSpec) []byte {
+	n := spec.width - utf8.RuneCount(dst[start:])
+	if n <= 0 {
+		return dst
+	}
+	if spec.minus {
+		return appendPadding(dst, ' ', n)
+	}
+
+	padByte := byte(' ')
+	if spec.zero {
+		padByte = '0'
+	}
+	end := len(dst)
+	dst = appendPadding(dst, padByte, n)
+	copy(dst[start+n:], dst[start:end])
+	for j := start; j < start+n; j++ {
+		dst[j] = padByte
+	}
+	return dst
+}
+
+// appendString appends a string, truncated to the precision in runes and
+// padded to the width.
+func appendString(dst []byte, str string, spec formatSpec) []byte {
+	if spec.precision >= 0 {
+		for i := range str {
+			if spec.precision == 0 {
+				str = str[:i]
+				break
+			}
+			spec.precision--
+		}
+	}
+	start := len(dst)
+	dst = append(dst, str...)
+	return padFrom(dst, start, spec)
+}
+
+// appendInteger appends an integer magnitude for an integer verb. %b, %o, %x
+// and %X are always prefixed (0b, 0o, 0x, 0X), as with tinystrconv, so the
+// '#' flag changes nothing. The precision sets the minimum number of digits
+// and the '0' flag pads the digits out to the width, which counts the sign
+// and prefix.
+func appendInteger(dst []byte, magnitude uint64, negative bool, verb byte, spec formatSpec) []byte {
+	base := uint64(integerBase(verb))
+	digitSet := lowerDigits
+	if verb == 'X' {
+		digitSet = upperDigits
+	}
+
+	isZero := magnitude == 0
+
+	// Collect the digits in reverse
+	var digits [64]byte
+	n := len(digits)
+	for magnitude >= base {
+		n--
+		digits[n] = digitSet[magnitude%base]
+		magnitude /= base
+	}
+	n--
+	digits[n] = digitSet[magnitude]
+
+	// Explicit precision zero prints nothing for the value zero, not even a
+	// sign or prefix, leaving only the padding
+	if spec.precision == 0 && isZero {
+		return appendPadding(dst, ' ', spec.width)
+	}
+
+	minDigits := spec.precision
+	if minDigits < 0 && spec.zero && spec.width > 0 {
+		minDigits = spec.width
+		if negative || spec.plus || spec.space {
+			minDigits-- // Leave room for the sign
+		}
+		if base != 10 {
+			minDigits -= 2 // And for the prefix
+		}
+	}
+
+	start := len(dst)
+	switch {
+	case negative:
+		dst = append(dst, '-')
+	case spec.plus:
+		dst = append(dst, '+')
+	case spec.space:
+		dst = append(dst, ' ')
+	}
+	switch verb {
+	case 'b':
+		dst = append(dst, '0', 'b')
+	case 'o':
+		dst = append(dst, '0', 'o')
+	case 'x':
+		dst = append(dst, '0', 'x')
+	case 'X':
+		dst = append(dst, '0', 'X')
+	}
+	dst = appendPadding(dst, '0', minDigits-(len(digits)-n))
+	dst = append(dst, digits[n:]...)
+
+	spec.zero = false
+	return padFrom(dst, start, spec)
+}
+
+// appendPointer appends an address for %p as 0x followed by lower-case hex
+// digits. The '#' flag leaves out the 0x.
+func appendPointer(dst []byte, address uint64, spec formatSpec) []byte {
+	if !spec.sharp {
+		return appendInteger(dst, address, false, 'x', spec)
+	}
+	start := len(dst)
+	dst = appendInteger(dst, address, false, 'x', defaultSpec)
+	dst = append(dst[:start], dst[start+2:]...) // Drop the 0x
+	return padFrom(dst, start, spec)
+}
+
+// appendHex appends the bytes of a string or byte slice as pairs of hex digits,
+// upper-case for %X. The precision limits the number of bytes encoded, the ' '
+// flag separates the bytes and the '#' flag prefixes them with 0x.
+func appendHex(dst []byte, str string, bytes []byte, verb byte, spec formatSpec) []byte {
+	digitSet := lowerDigits
+	if verb == 'X' {
+		digitSet = upperDigits
+	}
+	length := len(bytes)
+	if bytes == nil {
+		length = len(str)
+	}
+	if spec.precision >= 0 && spec.precision < length {
+		length = spec.precision
+	}
+
+	start := len(dst)
+	for i := 0; i < length; i++ {
+		if i > 0 && spec.space {
+			dst = append(dst, ' ')
+		}
+		if spec.sharp && (i == 0 || spec.space) {
+			dst = append(dst, '0', verb)
+		}
+		var c byte
+		if bytes == nil {
+			c = str[i]
+		} else {
+			c = bytes[i]
+		}
+		dst = append(dst, digitSet[c>>4], digitSet[c&0xf])
+	}
+	return padFrom(dst, start, spec)
+}
+
+// integerArgument splits any integer kind into its magnitude and sign. Named
+// integer types are resolved by namedInteger.
+func integerArgument(value interface{}) (magnitude uint64, negative bool, ok bool) {
+	var signed int64
+	switch value := value.(type) {
+	case int:
+		signed = int64(value)
+	case int8:
+		signed = int64(value)
+	case int16:
+		signed = int64(value)
+	case int32:
+		signed = int64(value)
+	case int64:
+		signed = value
+	case uint:
+		return uint64(value), false, true
+	case uint8:
+		return uint64(value), false, true
+	case uint16:
+		return uint64(value), false, true
+	case uint32:
+		return uint64(value), false, true
+	case uint64:
+		return value, false, true
+	case uintptr:
+		return uint64(value), false, true
+	default:
+		return namedInteger(value)
+	}
+	if signed < 0 {
+		return -uint64(signed), true, true
+	}
+	return uint64(signed), false, true
+}
+
+// isStringKind reports whether an argument is a string, including named
+// string types, which is what decides the spacing in print.
+func isStringKind(value interface{}) bool {
+	if _, ok := value.(string); ok {
+		return true
+	}
+	return isNamedString(value)
+}
+
+// integerBase returns the base used by an integer verb.
+func integerBase(verb byte) int {
+	switch verb {
+	case 'b':
+		return 2
+	case 'o':
+		return 8
+	case 'x', 'X':
+		return 16
+	default:
+		return 10
+	}
+}
+
+// floatArgument returns the value and bit size of any float kind. Named float
+// types are resolved by namedFloat.
+func floatArgument(value interface{}) (float64, int, bool) {
+	switch value := value.(type) {
+	case float32:
+		return float64(value), 32, true
+	case float64:
+		return value, 64, true
+	default:
+		return namedFloat(value)
+	}
+}
+
+// complexArgument returns the value and the bit size of each part of any
+// complex kind. Named complex types are resolved by namedComplex.
+func complexArgument(value interface{}) (complex128, int, bool) {
+	switch value := value.(type) {
+	case complex64:
+		return complex128(value), 32, true
+	case complex128:
+		return value, 64, true
+	default:
+		return namedComplex(value)
+	}
+}
+
+// appendComplex appends a complex number as (real+imaginary i), applying the
+// verb and spec to each part and always signing the imaginary part.
+func appendComplex(dst []byte, value complex128, bitSize int, verb byte, spec formatSpec) []byte {
+	dst = append(dst, '(')
+	dst = appendFloat(dst, real(value), bitSize, verb, spec)
+	spec.plus = true
+	dst = appendFloat(dst, imag(value), bitSize, verb, spec)
+	return append(dst, 'i', ')')
+}
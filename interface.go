@@ -0,0 +1,197 @@
+// =============================================================================
+// Project: tinyfmt
+// File: interface.go
+// Description: Interfaces that let types control their own formatting.
+// Datasheet/Docs:
+//
+// Author: Jason Duffy
+// Created on: 18/10/2026
+//
+// Copyright: (C) 2026, Jason Duffy
+// License: See LICENSE file in the project root for full license information.
+// Disclaimer: See DISCLAIMER file in the project root for full disclaimer.
+// =============================================================================
+
+// -------------------------------------------------------------------------- //
+//                               Import Statement                             //
+// -------------------------------------------------------------------------- //
+
+package tinyfmt
+
+import (
+	"io"
+	"unicode/utf8"
+)
+
+// -------------------------------------------------------------------------- //
+//               Public Consts, Structs & Variable Definitions                //
+// -------------------------------------------------------------------------- //
+
+// Stringer is implemented by any value that has a String method, which
+// defines the value's native format. It is used by Sprint, %v and %s.
+type Stringer interface {
+	String() string
+}
+
+// GoStringer is implemented by any value that has a GoString method, which
+// defines the value's Go syntax. It is used by %#v in place of Error and
+// String.
+type GoStringer interface {
+	GoString() string
+}
+
+// State is passed to a Formatter's Format method. It gives access to the
+// directive's flags, width and precision, and collects the output written to
+// it.
+type State interface {
+	io.Writer
+
+	// Width returns the width and whether it was set.
+	Width() (width int, ok bool)
+
+	// Precision returns the precision and whether it was set.
+	Precision() (precision int, ok bool)
+
+	// Flag reports whether the flag c ('-', '+', ' ', '0' or '#') was set.
+	Flag(c int) bool
+}
+
+// Formatter is implemented by any value that formats itself. Sprintf calls
+// Format for every verb, and Sprint and %v call it with 'v'. It takes
+// precedence over GoStringer, Stringer and error.
+type Formatter interface {
+	Format(state State, verb rune)
+}
+
+// -------------------------------------------------------------------------- //
+//                              Public Functions                              //
+// -------------------------------------------------------------------------- //
+
+// FormatString rebuilds the directive a Formatter was called for, such as
+// "%-8.2v", from its state and verb. A Format method can use it to pass its
+// flags, width and precision on to Sprintf.
+func FormatString(state State, verb rune) string {
+	directive := []byte{'%'}
+	for _, flag := range " +-#0" {
+		if state.Flag(int(flag)) {
+			directive = append(directive, byte(flag))
+		}
+	}
+	if width, ok := state.Width(); ok {
+		directive = appendInteger(directive, uint64(width), false, 'd', defaultSpec)
+	}
+	if precision, ok := state.Precision(); ok {
+		directive = append(directive, '.')
+		directive = appendInteger(directive, uint64(precision), false, 'd', defaultSpec)
+	}
+	directive = utf8.AppendRune(directive, verb)
+	return string(directive)
+}
+
+// -------------------------------------------------------------------------- //
+//               Private Consts, Structs & Variable Definitions               //
+// -------------------------------------------------------------------------- //
+
+// formatState is the State handed to a Formatter.
+type formatState struct {
+	buf  []byte
+	spec formatSpec
+}
+
+// -------------------------------------------------------------------------- //
+//                              Getters / Setters                             //
+// -------------------------------------------------------------------------- //
+
+// Write appends b to the output.
+func (s *formatState) Write(b []byte) (int, error) {
+	s.buf = append(s.buf, b...)
+	return len(b), nil
+}
+
+// Width returns the width and whether it was set.
+func (s *formatState) Width() (int, bool) {
+	return s.spec.width, s.spec.width >= 0
+}
+
+// Precision returns the precision and whether it was set.
+func (s *formatState) Precision() (int, bool) {
+	return s.spec.precision, s.spec.precision >= 0
+}
+
+// Flag reports whether the flag c was set.
+func (s *formatState) Flag(c int) bool {
+	switch c {
+	case '-':
+		return s.spec.minus
+	case '+':
+		return s.spec.plus
+	case ' ':
+		return s.spec.space
+	case '0':
+		return s.spec.zero
+	case '#':
+		return s.spec.sharp
+	}
+	return false
+}
+
+// -------------------------------------------------------------------------- //
+//                          Private Helper Functions                          //
+// -------------------------------------------------------------------------- //
+
+// appendFormatter appends the output of a value's Format method. A panic
+// inside the method is recovered and replaced with a marker.
+func appendFormatter(dst []byte, value Formatter, verb rune, spec formatSpec) (result []byte) {
+	state := formatState{buf: dst, spec: spec}
+
+	// Deferred calls run in reverse, so catchPanic sets the marker first
+	var marker string
+	defer func() {
+		if marker != "" {
+			result = append(state.buf, marker...)
+		}
+	}()
+	defer catchPanic(&marker, value, verb, "Format")
+
+	value.Format(&state, verb)
+	return state.buf
+}
+
+// handleMethods returns the result of a value's Error or String method, in
+// that order of preference, and whether it has either.
+func handleMethods(value interface{}, verb rune) (str string, handled bool) {
+	switch v := value.(type) {
+	case error:
+		handled = true
+		defer catchPanic(&str, value, verb, "Error")
+		str = v.Error()
+	case Stringer:
+		handled = true
+		defer catchPanic(&str, value, verb, "String")
+		str = v.String()
+	}
+	return str, handled
+}
+
+// handleGoString returns the result of a value's GoString method and whether
+// it has one.
+func handleGoString(value interface{}) (str string, handled bool) {
+	if v, ok := value.(GoStringer); ok {
+		handled = true
+		defer catchPanic(&str, value, 'v', "GoString")
+		str = v.GoString()
+	}
+	return str, handled
+}
+
+// catchPanic recovers from a panic in a value's Error or String method and
+// replaces the result with a marker. A nil pointer receiver prints as <nil>.
+func catchPanic(str *string, value interface{}, verb rune, method string) {
+	if err := recover(); err != nil {
+		if isNilPointer(value) {
+			*str = "<nil>"
+			return
+		}
+		*str = "%!" + string(verb) + "(PANIC=" + method + " method: " + Sprint(err) + ")"
+	}
+}
@@ -0,0 +1,229 @@
+// =============================================================================
+// Project: tinyfmt
+// File: interface_test.go
+// Description: Test suite for formatting interfaces in tinyfmt package.
+// Datasheet/Docs:
+//
+// Author: Jason Duffy
+// Created on: 18/10/2026
+//
+// Copyright: (C) 2026, Jason Duffy
+// License: See LICENSE file in the project root for full license information.
+// Disclaimer: See DISCLAIMER file in the project root for full disclaimer.
+// =============================================================================
+
+// -------------------------------------------------------------------------- //
+//                               Import Statement                             //
+// -------------------------------------------------------------------------- //
+
+package tinyfmt
+
+import (
+	"errors"
+	"testing"
+)
+
+// -------------------------------------------------------------------------- //
+//               Private Consts, Structs & Variable Definitions               //
+// -------------------------------------------------------------------------- //
+
+type testState uint8
+
+func (s testState) String() string {
+	switch s {
+	case 0:
+		return "idle"
+	case 1:
+		return "running"
+	default:
+		panic("unknown state")
+	}
+}
+
+type testID struct {
+	prefix string
+	number int
+}
+
+func (id *testID) String() string {
+	return id.prefix + "-" + Sprint(id.number)
+}
+
+type testError struct {
+	code int
+}
+
+func (e testError) Error() string {
+	return "error code " + Sprint(e.code)
+}
+
+func (e testError) String() string {
+	return "should not be used"
+}
+
+// testFixed is a Q8.8 fixed-point number that formats itself.
+type testFixed int16
+
+func (f testFixed) Format(state State, verb rune) {
+	switch verb {
+	case 'x', 'X':
+		str, _ := Sprintf("%06x", uint16(f))
+		state.Write([]byte(str))
+	case 'd':
+		state.Write([]byte(Sprint(int(f) >> 8)))
+	case 'v':
+		precision, ok := state.Precision()
+		if !ok {
+			precision = 2
+		}
+		str, _ := Sprintf("%."+Sprint(precision)+"f", float64(f)/256)
+		if state.Flag('+') && f >= 0 {
+			str = "+" + str
+		}
+		state.Write([]byte(str))
+	default:
+		state.Write([]byte("%!" + string(verb) + "(fixed)"))
+	}
+}
+
+// testPin prints as a constructor call in Go syntax.
+type testPin uint8
+
+func (p testPin) String() string {
+	return "P" + Sprint(uint8(p))
+}
+
+func (p testPin) GoString() string {
+	return "pin(" + Sprint(uint8(p)) + ")"
+}
+
+type testPanicGoStringer struct{}
+
+func (testPanicGoStringer) GoString() string {
+	panic("bad syntax")
+}
+
+type testPanicFormatter struct{}
+
+func (testPanicFormatter) Format(state State, verb rune) {
+	state.Write([]byte("partial"))
+	panic("bad format")
+}
+
+// -------------------------------------------------------------------------- //
+//                              Public Functions                              //
+// -------------------------------------------------------------------------- //
+
+func TestStringerAndError(t *testing.T) {
+	testCases := []struct {
+		format    string
+		arguments []interface{}
+		want      string
+	}{
+		{"%v", []interface{}{testState(1)}, "running"},                                 // Test Stringer with %v
+		{"%s", []interface{}{testState(0)}, "idle"},                                    // Test Stringer with %s
+		{"%-8s|", []interface{}{testState(0)}, "idle    |"},                            // Test padded Stringer
+		{"%s", []interface{}{&testID{"dev", 7}}, "dev-7"},                              // Test pointer receiver Stringer
+		{"%v", []interface{}{errors.New("boom")}, "boom"},                              // Test error with %v
+		{"%s", []interface{}{testError{42}}, "error code 42"},                          // Test error preferred over Stringer
+		{"%v", []interface{}{testState(9)}, "%!v(PANIC=String method: unknown state)"}, // Test panicking String method
+	}
+
+	for _, testCase := range testCases {
+		got, err := Sprintf(testCase.format, testCase.arguments...)
+		if err != nil {
+			t.Errorf("Sprintf(%q, %v) unexpected error: %v", testCase.format, testCase.arguments, err)
+			continue
+		}
+		if got != testCase.want {
+			t.Errorf("Sprintf(%q, %v) = %q, want %q", testCase.format, testCase.arguments, got, testCase.want)
+		}
+	}
+
+	if got := Sprint("state: ", testState(1), ", ", testError{7}); got != "state: running, error code 7" {
+		t.Errorf("Sprint with Stringer and error = %q, want %q", got, "state: running, error code 7")
+	}
+}
+
+func TestFormatter(t *testing.T) {
+	testCases := []struct {
+		format    string
+		arguments []interface{}
+		want      string
+	}{
+		{"%x", []interface{}{testFixed(0x0180)}, "0x0180"},                                         // Test Formatter with %x
+		{"%d", []interface{}{testFixed(0x0380)}, "3"},                                              // Test Formatter with %d
+		{"%v", []interface{}{testFixed(0x0180)}, "1.50"},                                           // Test Formatter with %v
+		{"%.1v", []interface{}{testFixed(0x0040)}, "0.2"},                                          // Test Formatter sees precision
+		{"%+v", []interface{}{testFixed(0x0100)}, "+1.00"},                                         // Test Formatter sees flags
+		{"%z", []interface{}{testFixed(1)}, "%!z(fixed)"},                                          // Test Formatter handles any verb
+		{"%é", []interface{}{testFixed(1)}, "%!é(fixed)"},                                          // Test Formatter sees a multibyte verb
+		{"%v", []interface{}{testPanicFormatter{}}, "partial%!v(PANIC=Format method: bad format)"}, // Test panicking Format method
+		{"%d%%", []interface{}{testFixed(0x6400)}, "100%"},                                         // Test escaped percent after Formatter
+	}
+
+	for _, testCase := range testCases {
+		got, err := Sprintf(testCase.format, testCase.arguments...)
+		if err != nil {
+			t.Errorf("Sprintf(%q, %v) unexpected error: %v", testCase.format, testCase.arguments, err)
+			continue
+		}
+		if got != testCase.want {
+			t.Errorf("Sprintf(%q, %v) = %q, want %q", testCase.format, testCase.arguments, got, testCase.want)
+		}
+	}
+
+	if got := Sprint("level=", testFixed(0x0280)); got != "level=2.50" {
+		t.Errorf("Sprint with Formatter = %q, want %q", got, "level=2.50")
+	}
+}
+
+func TestGoStringer(t *testing.T) {
+	testCases := []struct {
+		format    string
+		arguments []interface{}
+		want      string
+	}{
+		{"%#v", []interface{}{testPin(3)}, "pin(3)"},                                            // Test GoString with %#v
+		{"%v", []interface{}{testPin(3)}, "P3"},                                                 // Test String with %v
+		{"%+v", []interface{}{testPin(3)}, "P3"},                                                // Test String with %+v
+		{"%#9v|", []interface{}{testPin(3)}, "   pin(3)|"},                                      // Test GoString is padded
+		{"%#v", []interface{}{testFixed(0x0100)}, "1.00"},                                       // Test Formatter takes precedence
+		{"%#v", []interface{}{testPanicGoStringer{}}, "%!v(PANIC=GoString method: bad syntax)"}, // Test panicking GoString method
+		{"%#v", []interface{}{"hi"}, `"hi"`},                                                    // Test string is quoted
+		{"%#v", []interface{}{uint8(10)}, "0xa"},                                                // Test unsigned integer is hex
+		{"%#v", []interface{}{[]byte{1, 255}}, "[]byte{0x1, 0xff}"},                             // Test byte slice
+		{"%#v", []interface{}{nil}, "<nil>"},                                                    // Test nil
+	}
+
+	for _, testCase := range testCases {
+		got, err := Sprintf(testCase.format, testCase.arguments...)
+		if err != nil {
+			t.Errorf("Sprintf(%q, %v) unexpected error: %v", testCase.format, testCase.arguments, err)
+			continue
+		}
+		if got != testCase.want {
+			t.Errorf("Sprintf(%q, %v) = %q, want %q", testCase.format, testCase.arguments, got, testCase.want)
+		}
+	}
+}
+
+func TestFormatString(t *testing.T) {
+	testCases := []struct {
+		state formatState
+		verb  rune
+		want  string
+	}{
+		{formatState{spec: defaultSpec}, 'v', "%v"},                                                                   // Test no flags
+		{formatState{spec: formatSpec{plus: true, width: -1, precision: -1}}, 'v', "%+v"},                             // Test plus flag
+		{formatState{spec: formatSpec{minus: true, width: 8, precision: 2}}, 'f', "%-8.2f"},                           // Test width and precision
+		{formatState{spec: formatSpec{sharp: true, zero: true, space: true, width: 4, precision: -1}}, 'x', "% #04x"}, // Test flag order
+		{formatState{spec: formatSpec{width: -1, precision: 0}}, 'q', "%.0q"},                                         // Test zero precision
+	}
+
+	for _, testCase := range testCases {
+		if got := FormatString(&testCase.state, testCase.verb); got != testCase.want {
+			t.Errorf("FormatString(%+v, %q) = %q, want %q", testCase.state.spec, testCase.verb, got, testCase.want)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+//go:build tinyfmt_noreflect
+
+// =============================================================================
+// Project: tinyfmt
+// File: noreflect.go
+// Description: Reflection-free fallbacks used with the tinyfmt_noreflect tag.
+// Datasheet/Docs:
+//
+// Author: Jason Duffy
+// Created on: 18/10/2026
+//
+// Copyright: (C) 2026, Jason Duffy
+// License: See LICENSE file in the project root for full license information.
+// Disclaimer: See DISCLAIMER file in the project root for full disclaimer.
+// =============================================================================
+
+// -------------------------------------------------------------------------- //
+//                               Import Statement                             //
+// -------------------------------------------------------------------------- //
+
+package tinyfmt
+
+import (
+	"unsafe"
+)
+
+// -------------------------------------------------------------------------- //
+//                             Private Functions                              //
+// -------------------------------------------------------------------------- //
+
+// namedInteger would resolve named integer types, which needs reflection.
+func namedInteger(value interface{}) (magnitude uint64, negative bool, ok bool) {
+	return 0, false, false
+}
+
+// namedFloat would resolve named float types, which needs reflection.
+func namedFloat(value interface{}) (float64, int, bool) {
+	return 0, 0, false
+}
+
+// namedComplex would resolve named complex types, which needs reflection.
+func namedComplex(value interface{}) (complex128, int, bool) {
+	return 0, 0, false
+}
+
+// isNamedString would detect named string types, which needs reflection.
+func isNamedString(value interface{}) bool {
+	return false
+}
+
+// isNilPointer would detect nil pointers of any type, which needs
+// reflection, so a panicking method on a nil receiver prints a PANIC marker.
+func isNilPointer(value interface{}) bool {
+	return false
+}
+
+// pointerArgument returns the address %p prints for an unsafe.Pointer. Other
+// pointer types need reflection.
+func pointerArgument(value interface{}) (uint64, bool) {
+	if pointer, ok := value.(unsafe.Pointer); ok {
+		return uint64(uintptr(pointer)), true
+	}
+	return 0, false
+}
+
+// typeName returns the name of a predeclared type or a slice of one, <nil>
+// for nil or ? for any other type.
+func typeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "<nil>"
+	case bool:
+		return "bool"
+	case string:
+		return "string"
+	case int:
+		return "int"
+	case int8:
+		return "int8"
+	case int16:
+		return "int16"
+	case int32:
+		return "int32"
+	case int64:
+		return "int64"
+	case uint:
+		return "uint"
+	case uint8:
+		return "uint8"
+	case uint16:
+		return "uint16"
+	case uint32:
+		return "uint32"
+	case uint64:
+		return "uint64"
+	case uintptr:
+		return "uintptr"
+	case float32:
+		return "float32"
+	case float64:
+		return "float64"
+	case complex64:
+		return "complex64"
+	case complex128:
+		return "complex128"
+	case []interface{}:
+		return "[]interface {}"
+	case []string:
+		return "[]string"
+	case []bool:
+		return "[]bool"
+	case []int:
+		return "[]int"
+	case []int8:
+		return "[]int8"
+	case []int16:
+		return "[]int16"
+	case []int32:
+		return "[]int32"
+	case []int64:
+		return "[]int64"
+	case []uint:
+		return "[]uint"
+	case []uint8:
+		return "[]uint8"
+	case []uint16:
+		return "[]uint16"
+	case []uint32:
+		return "[]uint32"
+	case []uint64:
+		return "[]uint64"
+	case []float32:
+		return "[]float32"
+	case []float64:
+		return "[]float64"
+	case []error:
+		return "[]error"
+	default:
+		return "?"
+	}
+}
+
+// visit identifies a slice being printed. A slice of a slice starts at the
+// same address, so its length and type are part of the key too.
+type visit struct {
+	address uintptr
+	length  int
+	typ     string
+}
+
+// formatUnsupported formats the common slice types, which are found by a type
+// switch. Other composites cannot be walked without reflection.
+func formatUnsupported(value interface{}, mode valueMode) string {
+	var path [8]visit
+	if result, ok := appendSliceValue(nil, value, mode, 0, path[:0]); ok {
+		return string(result)
+	}
+	return "<unsupported>"
+}
+
+// appendSliceValue appends a value that is one of the common slice types, and
+// reports whether it was. Depth and path are as for appendSliceOf.
+func appendSliceValue(dst []byte, value interface{}, mode valueMode, depth int, path []visit) ([]byte, bool) {
+	switch value := value.(type) {
+	case []interface{}:
+		return appendSliceOf(dst, value, mode, depth, path), true
+	case []string:
+		return appendSliceOf(dst, value, mode, depth, path), true
+	case []bool:
+		return appendSliceOf(dst, value, mode, depth, path), true
+	case []int:
+		return appendSliceOf(dst, value, mode, depth, path), true
+	case []int8:
+		return appendSliceOf(dst, value, mode, depth, path), true
+	case []int16:
+		return appendSliceOf(dst, value, mode, depth, path), true
+	case []int32:
+		return appendSliceOf(dst, value, mode, depth, path), true
+	case []int64:
+		return appendSliceOf(dst, value, mode, depth, path), true
+	case []uint:
+		return appendSliceOf(dst, value, mode, depth, path), true
+	case []uint8:
+		return appendSliceOf(dst, value, mode, depth, path), true
+	case []uint16:
+		return appendSliceOf(dst, value, mode, depth, path), true
+	case []uint32:
+		return appendSliceOf(dst, value, mode, depth, path), true
+	case []uint64:
+		return appendSliceOf(dst, value, mode, depth, path), true
+	case []float32:
+		return appendSliceOf(dst, value, mode, depth, path), true
+	case []float64:
+		return appendSliceOf(dst, value, mode, depth, path), true
+	case []error:
+		return appendSliceOf(dst, value, mode, depth, path), true
+	default:
+		return dst, false
+	}
+}
+
+// appendSliceOf appends a slice of any element type that appendValue handles,
+// as []T{value, ...} for %#v. Slices nested in a []interface{} are walked
+// here too: depth counts how far in the slice is, and path holds the slices
+// it is inside, so one that contains itself prints as <cycle> rather than
+// recursing forever.
+func appendSliceOf[T any](dst []byte, values []T, mode valueMode, depth int, path []visit) []byte {
+	if depth >= currentMaxDepth() {
+		return append(dst, "..."...)
+	}
+	if len(values) > 0 {
+		key := visit{
+			address: uintptr(unsafe.Pointer(unsafe.SliceData(values))),
+			length:  len(values),
+			typ:     typeName(values),
+		}
+		for _, visiting := range path {
+			if visiting == key {
+				return append(dst, "<cycle>"...)
+			}
+		}
+		path = append(path, key)
+	}
+
+	if mode == goSyntaxValue {
+		dst = append(dst, typeName(values)...)
+		if values == nil {
+			return append(dst, "(nil)"...)
+		}
+		dst = append(dst, '{')
+	} else {
+		dst = append(dst, '[')
+	}
+	for i, value := range values {
+		if i > 0 {
+			if mode == goSyntaxValue {
+				dst = append(dst, ',')
+			}
+			dst = append(dst, ' ')
+		}
+		if nested, ok := appendSliceValue(dst, value, mode, depth+1, path); ok {
+			dst = nested
+			continue
+		}
+		dst = appendValue(dst, value, mode.spec())
+	}
+	if mode == goSyntaxValue {
+		return append(dst, '}')
+	}
+	return append(dst, ']')
+}
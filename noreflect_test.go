@@ -0,0 +1,129 @@
+//go:build tinyfmt_noreflect
+
+// =============================================================================
+// Project: tinyfmt
+// File: noreflect_test.go
+// Description: Test suite for the reflection-free build of tinyfmt package.
+// Datasheet/Docs:
+//
+// Author: Jason Duffy
+// Created on: 18/10/2026
+//
+// Copyright: (C) 2026, Jason Duffy
+// License: See LICENSE file in the project root for full license information.
+// Disclaimer: See DISCLAIMER file in the project root for full disclaimer.
+// =============================================================================
+
+// -------------------------------------------------------------------------- //
+//                               Import Statement                             //
+// -------------------------------------------------------------------------- //
+
+package tinyfmt
+
+import (
+	"errors"
+	"testing"
+)
+
+// -------------------------------------------------------------------------- //
+//               Private Consts, Structs & Variable Definitions               //
+// -------------------------------------------------------------------------- //
+
+type testRegister uint16
+
+type testExample struct {
+	Name  string
+	Value int
+}
+
+// -------------------------------------------------------------------------- //
+//                              Public Functions                              //
+// -------------------------------------------------------------------------- //
+
+func TestSprintNoReflect(t *testing.T) {
+	testCases := []struct {
+		arguments []interface{}
+		want      string
+	}{
+		{[]interface{}{[]string{"a", "b"}}, "[a b]"},                  // Test string slice
+		{[]interface{}{[]float64{0.5, 2}}, "[0.5 2]"},                 // Test float slice
+		{[]interface{}{[]interface{}{1, "x", true}}, "[1 x true]"},    // Test interface slice
+		{[]interface{}{[]error{errors.New("boom")}}, "[boom]"},        // Test error slice
+		{[]interface{}{nil}, "<nil>"},                                 // Test nil
+		{[]interface{}{[]interface{}{nil, 2}}, "[<nil> 2]"},           // Test nil element
+		{[]interface{}{testState(1)}, "running"},                      // Test Stringer on a named type
+		{[]interface{}{testFixed(384)}, "1.50"},                       // Test Formatter on a named type
+		{[]interface{}{map[string]int{"key": 1}}, "<unsupported>"},    // Test map needs reflection
+		{[]interface{}{testExample{"example", 123}}, "<unsupported>"}, // Test struct needs reflection
+		{[]interface{}{testRegister(7)}, "<unsupported>"},             // Test named integer needs reflection
+	}
+
+	for _, testCase := range testCases {
+		got := Sprint(testCase.arguments...)
+		if got != testCase.want {
+			t.Errorf("Sprint(%v) = %q, want %q", testCase.arguments, got, testCase.want)
+		}
+	}
+}
+
+func TestSprintfGoSyntaxNoReflect(t *testing.T) {
+	testCases := []struct {
+		value interface{}
+		want  string
+	}{
+		{[]int{1, 2}, "[]int{1, 2}"},                      // Test int slice
+		{[]string{"a"}, `[]string{"a"}`},                  // Test string slice is quoted
+		{[]uint16{10}, "[]uint16{0xa}"},                   // Test unsigned slice is hex
+		{[]interface{}{1, "x"}, `[]interface {}{1, "x"}`}, // Test interface slice
+		{[]float64(nil), "[]float64(nil)"},                // Test nil slice
+		{testExample{"example", 123}, "<unsupported>"},    // Test struct needs reflection
+	}
+
+	for _, testCase := range testCases {
+		if got, err := Sprintf("%#v", testCase.value); err != nil || got != testCase.want {
+			t.Errorf("Sprintf(%%#v, %v) = (%q, %v), want %q", testCase.value, got, err, testCase.want)
+		}
+	}
+}
+
+func TestSprintCyclesNoReflect(t *testing.T) {
+	cyclic := []interface{}{1, nil}
+	cyclic[1] = cyclic
+	if got := Sprint(cyclic); got != "[1 <cycle>]" {
+		t.Errorf("Sprint(cyclic) = %q, want %q", got, "[1 <cycle>]")
+	}
+	if got := Sprint([]interface{}{[]int{1}, []string{"a"}}); got != "[[1] [a]]" {
+		t.Errorf("Sprint(nested slices) = %q, want %q", got, "[[1] [a]]")
+	}
+	prefix := []interface{}{1, nil}
+	prefix[1] = prefix[:1]
+	if got := Sprint(prefix); got != "[1 [1]]" {
+		t.Errorf("Sprint(prefix) = %q, want %q", got, "[1 [1]]")
+	}
+
+	defer SetMaxDepth(0)
+	SetMaxDepth(2)
+	nested := []interface{}{1, []interface{}{2, []int{3}}}
+	if got := Sprint(nested); got != "[1 [2 ...]]" {
+		t.Errorf("Sprint(nested) with depth 2 = %q, want %q", got, "[1 [2 ...]]")
+	}
+}
+
+func TestFormatErrorNoReflect(t *testing.T) {
+	testCases := []struct {
+		format    string
+		arguments []interface{}
+		want      FormatError
+	}{
+		{"%d", []interface{}{"x"}, FormatError{WrongArgumentType, 0, 'd', 0, "string"}},        // Test predeclared type name
+		{"%d", []interface{}{testRegister(7)}, FormatError{WrongArgumentType, 0, 'd', 0, "?"}}, // Test other types are unnamed
+	}
+
+	for _, testCase := range testCases {
+		_, err := Sprintf(testCase.format, testCase.arguments...)
+		var formatErr *FormatError
+		if !errors.As(err, &formatErr) || *formatErr != testCase.want {
+			t.Errorf("Sprintf(%q, %v) error = %v, want %+v", testCase.format, testCase.arguments, err, testCase.want)
+		}
+	}
+}
@@ -19,7 +19,6 @@
 package tinyfmt
 
 import (
-	"errors"
 	"io"
 	"os"
 )
@@ -29,12 +28,10 @@ import (
 // -------------------------------------------------------------------------- //
 
 // PrintToIo formats according to a format specifier and writes to the provided io.Writer.
+// A formatting error is returned unchanged as a *FormatError and nothing is written.
+// Use Fprintf to also get the number of bytes written.
 func PrintToIo(w io.Writer, format string, arguments ...interface{}) error {
-	result, err := Sprintf(format, arguments...)
-	if err != nil {
-		return errors.New("failed to format the string")
-	}
-	_, err = w.Write([]byte(result))
+	_, err := Fprintf(w, format, arguments...)
 	return err
 }
 
@@ -42,3 +39,81 @@ func PrintToIo(w io.Writer, format string, arguments ...interface{}) error {
 func Printf(format string, arguments ...interface{}) error {
 	return PrintToIo(os.Stdout, format, arguments...)
 }
+
+// PrintToIoLenient formats like SprintfLenient and writes to the provided io.Writer.
+// The only error returned is the writer's.
+func PrintToIoLenient(w io.Writer, format string, arguments ...interface{}) error {
+	_, err := FprintfLenient(w, format, arguments...)
+	return err
+}
+
+// PrintfLenient formats like SprintfLenient and writes to os.Stdout.
+func PrintfLenient(format string, arguments ...interface{}) error {
+	return PrintToIoLenient(os.Stdout, format, arguments...)
+}
+
+// Fprint formats the arguments like Sprint and writes them to the provided io.Writer. It returns
+// the number of bytes written and the writer's error, or io.ErrShortWrite if the writer accepted
+// fewer bytes without saying why.
+func Fprint(w io.Writer, arguments ...interface{}) (n int, err error) {
+	p := newPrinter()
+	defer p.free()
+	p.print(arguments)
+	return write(w, p.buf)
+}
+
+// Fprintln formats the arguments like Sprintln and writes them to the provided io.Writer. It
+// returns the number of bytes written and any write error, as Fprint does.
+func Fprintln(w io.Writer, arguments ...interface{}) (n int, err error) {
+	p := newPrinter()
+	defer p.free()
+	p.println(arguments)
+	return write(w, p.buf)
+}
+
+// Fprintf formats according to a format specifier and writes to the provided io.Writer. It
+// returns the number of bytes written and any write error, as Fprint does. A formatting error is
+// returned unchanged as a *FormatError and nothing is written.
+func Fprintf(w io.Writer, format string, arguments ...interface{}) (n int, err error) {
+	p := newPrinter()
+	defer p.free()
+	if err := p.printf(format, arguments); err != nil {
+		return 0, err
+	}
+	return write(w, p.buf)
+}
+
+// FprintfLenient formats like SprintfLenient and writes to the provided io.Writer. It returns the
+// number of bytes written and any write error, as Fprint does.
+func FprintfLenient(w io.Writer, format string, arguments ...interface{}) (n int, err error) {
+	p := newPrinter()
+	defer p.free()
+	p.lenient = true
+	p.printf(format, arguments)
+	return write(w, p.buf)
+}
+
+// Print formats the arguments like Sprint and writes them to os.Stdout.
+func Print(arguments ...interface{}) (n int, err error) {
+	return Fprint(os.Stdout, arguments...)
+}
+
+// Println formats the arguments like Sprintln and writes them to os.Stdout.
+func Println(arguments ...interface{}) (n int, err error) {
+	return Fprintln(os.Stdout, arguments...)
+}
+
+// -------------------------------------------------------------------------- //
+//                             Private Functions                              //
+// -------------------------------------------------------------------------- //
+
+// write writes formatted output to w, passing on the writer's error. A
+// writer that accepts fewer bytes without an error has broken the io.Writer
+// contract, so this is reported as io.ErrShortWrite.
+func write(w io.Writer, data []byte) (int, error) {
+	n, err := w.Write(data)
+	if err == nil && n < len(data) {
+		err = io.ErrShortWrite
+	}
+	return n, err
+}
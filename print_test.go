@@ -20,10 +20,32 @@ package tinyfmt
 
 import (
 	"bytes"
+	"errors"
+	"io"
 	"os"
 	"testing"
 )
 
+// -------------------------------------------------------------------------- //
+//               Private Consts, Structs & Variable Definitions               //
+// -------------------------------------------------------------------------- //
+
+// limitedWriter accepts at most limit bytes per write, failing with err (which
+// may be nil, breaking the io.Writer contract) when it has to cut a write short.
+type limitedWriter struct {
+	buf   bytes.Buffer
+	limit int
+	err   error
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if len(p) <= w.limit {
+		return w.buf.Write(p)
+	}
+	w.buf.Write(p[:w.limit])
+	return w.limit, w.err
+}
+
 // -------------------------------------------------------------------------- //
 //                              Public Functions                              //
 // -------------------------------------------------------------------------- //
@@ -105,3 +127,127 @@ func TestPrintf(t *testing.T) {
 		}
 	}
 }
+
+func TestPrintToIoLenient(t *testing.T) {
+	var buf bytes.Buffer
+
+	testCases := []struct {
+		format    string
+		arguments []interface{}
+		want      string
+	}{
+		{"Hello, %s!", []interface{}{"world"}, "Hello, world!"},
+		{"Temp: %d C", []interface{}{"hot"}, "Temp: %!d(string=hot) C"},
+		{"%d/%d", []interface{}{1}, "1/%!d(MISSING)"},
+	}
+
+	for _, testCase := range testCases {
+		buf.Reset()
+		err := PrintToIoLenient(&buf, testCase.format, testCase.arguments...)
+		if err != nil {
+			t.Errorf("PrintToIoLenient(%q, %v) unexpected error: %v", testCase.format, testCase.arguments, err)
+			continue
+		}
+		got := buf.String()
+		if got != testCase.want {
+			t.Errorf("PrintToIoLenient(%q, %v) = %q, want %q", testCase.format, testCase.arguments, got, testCase.want)
+		}
+	}
+}
+
+func TestFprintFamily(t *testing.T) {
+	var buf bytes.Buffer
+
+	testCases := []struct {
+		name  string
+		print func() (int, error)
+		want  string
+	}{
+		{"Fprint", func() (int, error) { return Fprint(&buf, "x=", 1, 2) }, "x=1 2"},
+		{"Fprintln", func() (int, error) { return Fprintln(&buf, "x=", 1, 2) }, "x= 1 2\n"},
+		{"Fprintf", func() (int, error) { return Fprintf(&buf, "x=%d,%d", 1, 2) }, "x=1,2"},
+	}
+
+	for _, testCase := range testCases {
+		buf.Reset()
+		n, err := testCase.print()
+		if err != nil {
+			t.Errorf("%s unexpected error: %v", testCase.name, err)
+			continue
+		}
+		if got := buf.String(); got != testCase.want || n != len(testCase.want) {
+			t.Errorf("%s = %q (n=%d), want %q (n=%d)", testCase.name, got, n, testCase.want, len(testCase.want))
+		}
+	}
+
+	// A formatting error writes nothing
+	buf.Reset()
+	if n, err := Fprintf(&buf, "%d", "x"); err == nil || n != 0 || buf.Len() != 0 {
+		t.Errorf("Fprintf with a bad argument = (%d, %v), wrote %q", n, err, buf.String())
+	}
+}
+
+func TestPrintAndPrintln(t *testing.T) {
+	// Redirect os.Stdout to capture the output
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	Print("a", 1, 2, "b")
+	Println("c", 3)
+
+	// Close the writer and restore os.Stdout
+	w.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	os.Stdout = old
+
+	if got, want := buf.String(), "a1 2bc 3\n"; got != want {
+		t.Errorf("Print and Println wrote %q, want %q", got, want)
+	}
+}
+
+func TestFprintWriteErrors(t *testing.T) {
+	errUART := errors.New("uart: tx fifo full")
+
+	testCases := []struct {
+		name    string
+		writer  *limitedWriter
+		wantN   int
+		wantErr error
+	}{
+		{"complete write", &limitedWriter{limit: 64}, 7, nil},                // Test byte count of a full write
+		{"writer error", &limitedWriter{limit: 3, err: errUART}, 3, errUART}, // Test the writer's error is passed on
+		{"short write", &limitedWriter{limit: 5}, 5, io.ErrShortWrite},       // Test a silent short write is reported
+	}
+
+	for _, testCase := range testCases {
+		writes := []struct {
+			name  string
+			write func(w io.Writer) (int, error)
+		}{
+			{"Fprintf", func(w io.Writer) (int, error) { return Fprintf(w, "id=%04d", 7) }},
+			{"FprintfLenient", func(w io.Writer) (int, error) { return FprintfLenient(w, "id=%04d", 7) }},
+			{"Fprint", func(w io.Writer) (int, error) { return Fprint(w, "id=", "0007") }},
+			{"Fprintln", func(w io.Writer) (int, error) { return Fprintln(w, "id=0007") }},
+		}
+
+		for _, write := range writes {
+			testCase.writer.buf.Reset()
+			wantN := testCase.wantN
+			if write.name == "Fprintln" && testCase.wantErr == nil {
+				wantN++ // The newline
+			}
+			n, err := write.write(testCase.writer)
+			if n != wantN || !errors.Is(err, testCase.wantErr) {
+				t.Errorf("%s with %s = (%d, %v), want (%d, %v)", write.name, testCase.name, n, err, wantN, testCase.wantErr)
+			}
+		}
+
+		// PrintToIo reports the same error without the count
+		testCase.writer.buf.Reset()
+		if err := PrintToIo(testCase.writer, "id=%04d", 7); !errors.Is(err, testCase.wantErr) {
+			t.Errorf("PrintToIo with %s error = %v, want %v", testCase.name, err, testCase.wantErr)
+		}
+	}
+}
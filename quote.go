@@ -0,0 +1,237 @@
+// =============================================================================
+// Project: tinyfmt
+// File: quote.go
+// Description: Quoting and rune helpers for the %q, %c and %U verbs.
+// Datasheet/Docs: https://go.dev/ref/spec#Rune_literals
+//
+// Author: Jason Duffy
+// Created on: 18/10/2026
+//
+// Copyright: (C) 2026, Jason Duffy
+// License: See LICENSE file in the project root for full license information.
+// Disclaimer: See DISCLAIMER file in the project root for full disclaimer.
+// =============================================================================
+
+// -------------------------------------------------------------------------- //
+//                               Import Statement                             //
+// -------------------------------------------------------------------------- //
+
+package tinyfmt
+
+import (
+	"unicode/utf8"
+)
+
+// -------------------------------------------------------------------------- //
+//               Private Consts, Structs & Variable Definitions               //
+// -------------------------------------------------------------------------- //
+
+// nonPrintRanges lists the runes, in inclusive pairs, that isPrint treats as
+// not printable: spaces other than U+0020, format characters, private use
+// areas and noncharacters. This stands in for the Unicode tables so they are
+// not linked into the binary.
+var nonPrintRanges = [...]rune{
+	0x00a0, 0x00a0, // No-break space
+	0x00ad, 0x00ad, // Soft hyphen
+	0x061c, 0x061c, // Arabic letter mark
+	0x1680, 0x1680, // Ogham space mark
+	0x180e, 0x180e, // Mongolian vowel separator
+	0x2000, 0x200f, // Spaces, zero width characters and direction marks
+	0x2028, 0x202f, // Line and paragraph separators, embedding controls
+	0x205f, 0x206f, // Medium mathematical space, invisible operators
+	0x3000, 0x3000, // Ideographic space
+	0xd800, 0xdfff, // Surrogates
+	0xe000, 0xf8ff, // Private use area
+	0xfeff, 0xfeff, // Byte order mark
+	0xfff9, 0xfffb, // Interlinear annotations
+	0xfffe, 0xffff, // Noncharacters
+	0xe0000, 0xe007f, // Tags
+	0xf0000, 0x10ffff, // Supplementary private use areas
+}
+
+// -------------------------------------------------------------------------- //
+//                          Private Helper Functions                          //
+// -------------------------------------------------------------------------- //
+
+// runeArgument converts an integer argument to a rune, substituting U+FFFD
+// for values outside the Unicode range.
+func runeArgument(magnitude uint64, negative bool) rune {
+	if negative || magnitude > utf8.MaxRune {
+		return utf8.RuneError
+	}
+	return rune(magnitude)
+}
+
+// appendQuotedString appends a string as a double-quoted Go string literal.
+// The '+' flag escapes all non-ASCII runes and the '#' flag uses a raw
+// (backquoted) literal when the string allows it.
+func appendQuotedString(dst []byte, str string, spec formatSpec) []byte {
+	if spec.precision >= 0 {
+		for i := range str {
+			if spec.precision == 0 {
+				str = str[:i]
+				break
+			}
+			spec.precision--
+		}
+	}
+
+	start := len(dst)
+	if spec.sharp && canBackquote(str) {
+		dst = append(dst, '`')
+		dst = append(dst, str...)
+		dst = append(dst, '`')
+		return padFrom(dst, start, spec)
+	}
+
+	dst = append(dst, '"')
+	for len(str) > 0 {
+		r, size := utf8.DecodeRuneInString(str)
+		if size == 1 && r == utf8.RuneError {
+			// Invalid UTF-8 is written byte by byte
+			dst = append(dst, '\\', 'x', lowerDigits[str[0]>>4], lowerDigits[str[0]&0xf])
+		} else {
+			dst = appendEscapedRune(dst, r, '"', spec.plus)
+		}
+		str = str[size:]
+	}
+	dst = append(dst, '"')
+	return padFrom(dst, start, spec)
+}
+
+// appendQuotedRune appends a rune as a single-quoted Go character literal.
+// The '+' flag escapes non-ASCII runes.
+func appendQuotedRune(dst []byte, r rune, spec formatSpec) []byte {
+	start := len(dst)
+	dst = append(dst, '\'')
+	dst = appendEscapedRune(dst, r, '\'', spec.plus)
+	dst = append(dst, '\'')
+	return padFrom(dst, start, spec)
+}
+
+// appendRune appends a rune encoded as UTF-8.
+func appendRune(dst []byte, r rune, spec formatSpec) []byte {
+	start := len(dst)
+	dst = utf8.AppendRune(dst, r)
+	return padFrom(dst, start, spec)
+}
+
+// appendUnicode appends a code point as U+XXXX with at least four (or the
+// precision's) upper-case hex digits. The '#' flag adds the quoted character
+// when it is printable.
+func appendUnicode(dst []byte, magnitude uint64, spec formatSpec) []byte {
+	var digits [16]byte
+	n := len(digits)
+	for value := magnitude; value > 0 || n == len(digits); value >>= 4 {
+		n--
+		digits[n] = upperDigits[value&0xf]
+	}
+
+	minDigits := spec.precision
+	if minDigits < 4 {
+		minDigits = 4
+	}
+
+	start := len(dst)
+	dst = append(dst, 'U', '+')
+	dst = appendPadding(dst, '0', minDigits-(len(digits)-n))
+	dst = append(dst, digits[n:]...)
+	if spec.sharp && magnitude <= utf8.MaxRune && isPrint(rune(magnitude)) {
+		dst = append(dst, ' ', '\'')
+		dst = utf8.AppendRune(dst, rune(magnitude))
+		dst = append(dst, '\'')
+	}
+
+	spec.zero = false
+	return padFrom(dst, start, spec)
+}
+
+// appendEscapedRune appends a rune as it appears inside a quoted literal,
+// escaping the quote character, backslashes and anything not printable.
+func appendEscapedRune(dst []byte, r rune, quote byte, asciiOnly bool) []byte {
+	// Surrogates and out of range values are quoted as the replacement rune
+	if !utf8.ValidRune(r) {
+		r = utf8.RuneError
+	}
+	if r == rune(quote) || r == '\\' {
+		return append(dst, '\\', byte(r))
+	}
+	if isPrint(r) && (!asciiOnly || r < utf8.RuneSelf) {
+		return utf8.AppendRune(dst, r)
+	}
+
+	switch r {
+	case '\a':
+		return append(dst, '\\', 'a')
+	case '\b':
+		return append(dst, '\\', 'b')
+	case '\f':
+		return append(dst, '\\', 'f')
+	case '\n':
+		return append(dst, '\\', 'n')
+	case '\r':
+		return append(dst, '\\', 'r')
+	case '\t':
+		return append(dst, '\\', 't')
+	case '\v':
+		return append(dst, '\\', 'v')
+	}
+
+	switch {
+	case r < ' ' || r == 0x7f:
+		return append(dst, '\\', 'x', lowerDigits[r>>4], lowerDigits[r&0xf])
+	case r < 0x10000:
+		dst = append(dst, '\\', 'u')
+		for shift := 12; shift >= 0; shift -= 4 {
+			dst = append(dst, lowerDigits[r>>uint(shift)&0xf])
+		}
+		return dst
+	default:
+		dst = append(dst, '\\', 'U')
+		for shift := 28; shift >= 0; shift -= 4 {
+			dst = append(dst, lowerDigits[r>>uint(shift)&0xf])
+		}
+		return dst
+	}
+}
+
+// isPrint reports whether a rune is printed as itself inside a quoted
+// literal. Without the Unicode tables, every valid rune outside the control
+// characters and nonPrintRanges is treated as printable.
+func isPrint(r rune) bool {
+	if r < 0x20 || (r >= 0x7f && r < 0xa0) || r > utf8.MaxRune {
+		return false
+	}
+	if r < 0xa0 {
+		return true
+	}
+	for i := 0; i < len(nonPrintRanges); i += 2 {
+		if r >= nonPrintRanges[i] && r <= nonPrintRanges[i+1] {
+			return false
+		}
+	}
+	// The last two code points of every plane are noncharacters
+	return r&0xfffe != 0xfffe
+}
+
+// canBackquote reports whether a string can be written as a raw string
+// literal without changing its meaning.
+func canBackquote(str string) bool {
+	for len(str) > 0 {
+		r, size := utf8.DecodeRuneInString(str)
+		str = str[size:]
+		if size > 1 {
+			if r == 0xfeff {
+				return false
+			}
+			continue
+		}
+		if r == utf8.RuneError {
+			return false
+		}
+		if (r < ' ' && r != '\t') || r == '`' || r == 0x7f {
+			return false
+		}
+	}
+	return true
+}
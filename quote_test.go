@@ -0,0 +1,90 @@
+// =============================================================================
+// Project: tinyfmt
+// File: quote_test.go
+// Description: Test suite for quoting verbs in tinyfmt package.
+// Datasheet/Docs:
+//
+// Author: Jason Duffy
+// Created on: 18/10/2026
+//
+// Copyright: (C) 2026, Jason Duffy
+// License: See LICENSE file in the project root for full license information.
+// Disclaimer: See DISCLAIMER file in the project root for full disclaimer.
+// =============================================================================
+
+// -------------------------------------------------------------------------- //
+//                               Import Statement                             //
+// -------------------------------------------------------------------------- //
+
+package tinyfmt
+
+import (
+	"strconv"
+	"testing"
+)
+
+// -------------------------------------------------------------------------- //
+//                              Public Functions                              //
+// -------------------------------------------------------------------------- //
+
+func TestSprintfQuoteVerbs(t *testing.T) {
+	testCases := []struct {
+		format    string
+		arguments []interface{}
+		want      string
+		shouldErr bool
+	}{
+		{"%q", []interface{}{"héllo\n\"x\"\x00\xff"}, `"héllo\n\"x\"\x00\xff"`, false},                     // Test escaped string
+		{"%+q", []interface{}{"héllo\u263a"}, `"h\u00e9llo\u263a"`, false},                                 // Test ASCII-only string
+		{"%#q", []interface{}{`ab\c`}, "`ab\\c`", false},                                                   // Test backquoted string
+		{"%#q", []interface{}{"a`b"}, "\"a`b\"", false},                                                    // Test backquote falls back to quotes
+		{"%q", []interface{}{[]byte("hi")}, `"hi"`, false},                                                 // Test quoted byte slice
+		{"%.2q", []interface{}{"hello"}, `"he"`, false},                                                    // Test quoted string with precision
+		{"%q", []interface{}{"\u00a0\u200b\ufeff\u00ad\ue000"}, `"\u00a0\u200b\ufeff\u00ad\ue000"`, false}, // Test non-printable runes
+		{"%q", []interface{}{"\U0001F600\U000E0001"}, "\"\U0001F600\\U000e0001\"", false},                  // Test supplementary runes
+		{"%q", []interface{}{'x'}, `'x'`, false},                                                           // Test quoted rune
+		{"%q", []interface{}{'\''}, `'\''`, false},                                                         // Test escaped single quote
+		{"%q", []interface{}{'"'}, `'"'`, false},                                                           // Test double quote in rune
+		{"%+q", []interface{}{'\u263a'}, `'\u263a'`, false},                                                // Test ASCII-only rune
+		{"%q", []interface{}{-1}, "'\ufffd'", false},                                                       // Test out of range rune
+		{"%q", []interface{}{0xd800}, "'\ufffd'", false},                                                   // Test surrogate rune
+		{"%q", []interface{}{0x110000}, "'\ufffd'", false},                                                 // Test rune above the maximum
+		{"%+q", []interface{}{0xdfff}, `'\ufffd'`, false},                                                  // Test ASCII-only surrogate rune
+		{"%-6q|", []interface{}{'z'}, `'z'   |`, false},                                                    // Test padded quoted rune
+		{"%c", []interface{}{0x263a}, "\u263a", false},                                                     // Test rune from integer
+		{"%c", []interface{}{byte('A')}, "A", false},                                                       // Test rune from byte
+		{"%5c|", []interface{}{'x'}, "    x|", false},                                                      // Test padded rune
+		{"%U", []interface{}{0x1f600}, "U+1F600", false},                                                   // Test code point
+		{"%U", []interface{}{'x'}, "U+0078", false},                                                        // Test short code point
+		{"%#U", []interface{}{'x'}, "U+0078 'x'", false},                                                   // Test code point with character
+		{"%#U", []interface{}{'\n'}, "U+000A", false},                                                      // Test code point without unprintable character
+		{"%.6U", []interface{}{0x41}, "U+000041", false},                                                   // Test code point with precision
+		{"%8U|", []interface{}{0x41}, "  U+0041|", false},                                                  // Test padded code point
+		{"%U", []interface{}{-7}, "U+FFFFFFFFFFFFFFF9", false},                                             // Test negative code point
+		{"%q", []interface{}{3.5}, "", true},                                                               // Test float for %q
+		{"%c", []interface{}{"x"}, "", true},                                                               // Test string for %c
+		{"%U", []interface{}{}, "", true},                                                                  // Test missing argument for %U
+	}
+
+	for _, testCase := range testCases {
+		got, err := Sprintf(testCase.format, testCase.arguments...)
+		if (err != nil) != testCase.shouldErr {
+			t.Errorf("Sprintf(%q, %v) error = %v, wantErr %v", testCase.format, testCase.arguments, err, testCase.shouldErr)
+			continue
+		}
+		if got != testCase.want {
+			t.Errorf("Sprintf(%q, %v) = %q, want %q", testCase.format, testCase.arguments, got, testCase.want)
+		}
+	}
+}
+
+// Cross-check quoting against strconv over the Basic Multilingual Plane
+func TestQuoteMatchesStrconv(t *testing.T) {
+	for r := rune(0); r < 0x800; r++ {
+		got := string(appendQuotedRune(nil, r, defaultSpec))
+		want := strconv.QuoteRune(r)
+		if got != want && strconv.IsPrint(r) == isPrint(r) {
+			t.Errorf("appendQuotedRune(%U) = %s, want %s", r, got, want)
+		}
+	}
+}
@@ -0,0 +1,478 @@
+//go:build !tinyfmt_noreflect
+
+// =============================================================================
+// Project: tinyfmt
+// File: reflect.go
+// Description: Reflection-based formatting of named types and composites.
+// Datasheet/Docs:
+//
+// Author: Jason Duffy
+// Created on: 18/10/2026
+//
+// Copyright: (C) 2026, Jason Duffy
+// License: See LICENSE file in the project root for full license information.
+// Disclaimer: See DISCLAIMER file in the project root for full disclaimer.
+// =============================================================================
+
+// -------------------------------------------------------------------------- //
+//                               Import Statement                             //
+// -------------------------------------------------------------------------- //
+
+package tinyfmt
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/Jason-Duffy/tinystrconv"
+)
+
+// -------------------------------------------------------------------------- //
+//                             Private Functions                              //
+// -------------------------------------------------------------------------- //
+
+// namedInteger splits a value of a named integer type, such as
+// type Register uint16, into its magnitude and sign.
+func namedInteger(value interface{}) (magnitude uint64, negative bool, ok bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		signed := v.Int()
+		if signed < 0 {
+			return -uint64(signed), true, true
+		}
+		return uint64(signed), false, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint(), false, true
+	default:
+		return 0, false, false
+	}
+}
+
+// namedFloat returns the value and bit size of a named float type.
+func namedFloat(value interface{}) (float64, int, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Float32:
+		return v.Float(), 32, true
+	case reflect.Float64:
+		return v.Float(), 64, true
+	default:
+		return 0, 0, false
+	}
+}
+
+// namedComplex returns the value and part bit size of a named complex type.
+func namedComplex(value interface{}) (complex128, int, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Complex64:
+		return v.Complex(), 32, true
+	case reflect.Complex128:
+		return v.Complex(), 64, true
+	default:
+		return 0, 0, false
+	}
+}
+
+// isNamedString reports whether a value's type has the string kind.
+func isNamedString(value interface{}) bool {
+	return value != nil && reflect.TypeOf(value).Kind() == reflect.String
+}
+
+// isNilPointer reports whether a value is a nil pointer of any type.
+func isNilPointer(value interface{}) bool {
+	v := reflect.ValueOf(value)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
+
+// pointerArgument returns the address %p prints for a pointer, channel,
+// function, map, slice or unsafe.Pointer.
+func pointerArgument(value interface{}) (uint64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Chan, reflect.Func, reflect.Map, reflect.Slice, reflect.UnsafePointer:
+		return uint64(v.Pointer()), true
+	default:
+		return 0, false
+	}
+}
+
+// typeName returns the name of a value's type, or <nil> for nil.
+func typeName(value interface{}) string {
+	if value == nil {
+		return "<nil>"
+	}
+	return reflect.TypeOf(value).String()
+}
+
+// walker holds the state of one walk through a value.
+type walker struct {
+	mode     valueMode
+	maxDepth int     // Depth at which composites print as ...
+	path     []visit // Slices and maps being printed, outermost first
+}
+
+// visit identifies a slice or map being printed. A slice of a slice starts
+// at the same address, so its length and type are part of the key too.
+type visit struct {
+	address uintptr
+	length  int
+	typ     reflect.Type
+}
+
+// formatUnsupported formats the kinds appendValue does not handle itself,
+// walking composites through reflection.
+func formatUnsupported(value interface{}, mode valueMode) string {
+	var path [8]visit
+	w := walker{mode: mode, maxDepth: currentMaxDepth(), path: path[:0]}
+	return string(appendReflectValue(nil, reflect.ValueOf(value), 0, &w))
+}
+
+// appendReflectValue appends a value by reading it through reflection. It
+// never calls Interface on the value itself, so unexported struct fields and
+// anything inside them are printed read-only instead of panicking. Depth is
+// zero for a top-level argument, where a pointer to a composite prints as
+// &{...} rather than as an address. Composites nested deeper than the
+// walker's maximum depth print as ..., and a slice or map that contains
+// itself prints as <cycle> where it recurs.
+func appendReflectValue(dst []byte, v reflect.Value, depth int, w *walker) []byte {
+	switch v.Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+		if depth >= w.maxDepth {
+			return append(dst, "..."...)
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Invalid:
+		return append(dst, "<nil>"...)
+	case reflect.Struct:
+		return appendStruct(dst, v, depth, w)
+	case reflect.Slice, reflect.Array:
+		if !w.enter(v) {
+			return append(dst, "<cycle>"...)
+		}
+		dst = appendSlice(dst, v, depth, w)
+		w.leave(v)
+		return dst
+	case reflect.Map:
+		if !w.enter(v) {
+			return append(dst, "<cycle>"...)
+		}
+		dst = appendMap(dst, v, depth, w)
+		w.leave(v)
+		return dst
+	case reflect.Interface:
+		if !v.IsNil() {
+			return appendReflectValue(dst, v.Elem(), depth, w)
+		}
+		if w.mode == goSyntaxValue {
+			dst = append(dst, v.Type().String()...)
+			return append(dst, "(nil)"...)
+		}
+		return append(dst, "<nil>"...)
+	case reflect.Pointer:
+		if depth == 0 && !v.IsNil() {
+			switch v.Elem().Kind() {
+			case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+				dst = append(dst, '&')
+				return appendReflectValue(dst, v.Elem(), depth+1, w)
+			}
+		}
+		return appendAddress(dst, v, w.mode)
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return appendAddress(dst, v, w.mode)
+	case reflect.String:
+		if w.mode == goSyntaxValue {
+			return appendQuotedString(dst, v.String(), defaultSpec)
+		}
+		return append(dst, v.String()...)
+	case reflect.Bool:
+		return append(dst, tinystrconv.BoolToString(v.Bool())...)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		signed := v.Int()
+		if signed < 0 {
+			return appendInteger(dst, -uint64(signed), true, 'd', defaultSpec)
+		}
+		return appendInteger(dst, uint64(signed), false, 'd', defaultSpec)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if w.mode == goSyntaxValue {
+			return appendInteger(dst, v.Uint(), false, 'x', defaultSpec)
+		}
+		return appendInteger(dst, v.Uint(), false, 'd', defaultSpec)
+	case reflect.Float32:
+		return appendFloat(dst, v.Float(), 32, 'g', defaultSpec)
+	case reflect.Float64:
+		return appendFloat(dst, v.Float(), 64, 'g', defaultSpec)
+	case reflect.Complex64:
+		return appendComplex(dst, v.Complex(), 32, 'g', defaultSpec)
+	case reflect.Complex128:
+		return appendComplex(dst, v.Complex(), 64, 'g', defaultSpec)
+	default:
+		return append(dst, "<unsupported>"...)
+	}
+}
+
+// appendElement appends a field, element, key or value of a composite. If it
+// is exported its Format, Error or String method is used, as for a
+// top-level argument, or its GoString method for %#v; otherwise it is read
+// through reflection without calling methods, as with fmt. Interfaces print
+// their dynamic value.
+func appendElement(dst []byte, v reflect.Value, depth int, w *walker) []byte {
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.CanInterface() {
+		value := v.Interface()
+		if formatter, ok := value.(Formatter); ok {
+			return appendFormatter(dst, formatter, 'v', w.mode.spec())
+		}
+		if w.mode == goSyntaxValue {
+			if str, ok := handleGoString(value); ok {
+				return append(dst, str...)
+			}
+		} else if str, ok := handleMethods(value, 'v'); ok {
+			return append(dst, str...)
+		}
+	}
+	return appendReflectValue(dst, v, depth, w)
+}
+
+// appendStruct appends a struct as {value ...}, {Name:value ...} for %+v or
+// pkg.Type{Name:value, ...} for %#v.
+func appendStruct(dst []byte, v reflect.Value, depth int, w *walker) []byte {
+	if w.mode == goSyntaxValue {
+		dst = append(dst, v.Type().String()...)
+	}
+	dst = append(dst, '{')
+	for i := 0; i < v.NumField(); i++ {
+		if i > 0 {
+			dst = appendSeparator(dst, w.mode)
+		}
+		if w.mode != plainValue {
+			dst = append(dst, v.Type().Field(i).Name...)
+			dst = append(dst, ':')
+		}
+		dst = appendElement(dst, v.Field(i), depth+1, w)
+	}
+	return append(dst, '}')
+}
+
+// appendSlice appends a slice or array as [value ...], or as
+// []T{value, ...} for %#v.
+func appendSlice(dst []byte, v reflect.Value, depth int, w *walker) []byte {
+	if w.mode == goSyntaxValue {
+		dst = append(dst, v.Type().String()...)
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return append(dst, "(nil)"...)
+		}
+		dst = append(dst, '{')
+	} else {
+		dst = append(dst, '[')
+	}
+	for i := 0; i < v.Len(); i++ {
+		if i > 0 {
+			dst = appendSeparator(dst, w.mode)
+		}
+		dst = appendElement(dst, v.Index(i), depth+1, w)
+	}
+	if w.mode == goSyntaxValue {
+		return append(dst, '}')
+	}
+	return append(dst, ']')
+}
+
+// appendMap appends a map as {key:value ...}, or as map[K]V{key:value, ...}
+// for %#v. Keys are sorted as fmt sorts them, so the output is the same on
+// every run.
+func appendMap(dst []byte, v reflect.Value, depth int, w *walker) []byte {
+	if w.mode == goSyntaxValue {
+		dst = append(dst, v.Type().String()...)
+		if v.IsNil() {
+			return append(dst, "(nil)"...)
+		}
+	}
+	dst = append(dst, '{')
+	entries := sortedMap(v)
+	for i := range entries.keys {
+		if i > 0 {
+			dst = appendSeparator(dst, w.mode)
+		}
+		dst = appendElement(dst, entries.keys[i], depth+1, w)
+		dst = append(dst, ':')
+		dst = appendElement(dst, entries.values[i], depth+1, w)
+	}
+	return append(dst, '}')
+}
+
+// enter records that the slice or map v is being printed, reporting false if
+// it already is further out, which means it contains itself. Arrays and empty
+// values cannot recur and are not recorded.
+func (w *walker) enter(v reflect.Value) bool {
+	if v.Kind() == reflect.Array || v.Len() == 0 {
+		return true
+	}
+	key := visit{address: v.Pointer(), length: v.Len(), typ: v.Type()}
+	for _, visiting := range w.path {
+		if visiting == key {
+			return false
+		}
+	}
+	w.path = append(w.path, key)
+	return true
+}
+
+// leave removes the slice or map v recorded by enter.
+func (w *walker) leave(v reflect.Value) {
+	if v.Kind() != reflect.Array && v.Len() > 0 {
+		w.path = w.path[:len(w.path)-1]
+	}
+}
+
+// mapEntries holds a map's keys and values, in matching order. Entries are
+// read with an iterator rather than looked up by key, since a NaN key cannot
+// be looked up.
+type mapEntries struct {
+	keys   []reflect.Value
+	values []reflect.Value
+}
+
+func (m *mapEntries) Len() int           { return len(m.keys) }
+func (m *mapEntries) Less(i, j int) bool { return compareKeys(m.keys[i], m.keys[j]) < 0 }
+func (m *mapEntries) Swap(i, j int) {
+	m.keys[i], m.keys[j] = m.keys[j], m.keys[i]
+	m.values[i], m.values[j] = m.values[j], m.values[i]
+}
+
+// sortedMap returns a map's entries sorted by key.
+func sortedMap(v reflect.Value) *mapEntries {
+	entries := &mapEntries{
+		keys:   make([]reflect.Value, 0, v.Len()),
+		values: make([]reflect.Value, 0, v.Len()),
+	}
+	for iter := v.MapRange(); iter.Next(); {
+		entries.keys = append(entries.keys, iter.Key())
+		entries.values = append(entries.values, iter.Value())
+	}
+	sort.Stable(entries)
+	return entries
+}
+
+// compareKeys orders two map keys of the same type as fmt does, returning -1,
+// 0 or 1. Numbers and strings sort by value, false before true, NaN before
+// other floats, pointers and channels by address, and structs and arrays
+// field by field. Interface keys sort nil first, then by type, then by value.
+func compareKeys(a, b reflect.Value) int {
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compareOrdered(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return compareOrdered(a.Uint(), b.Uint())
+	case reflect.String:
+		return compareOrdered(a.String(), b.String())
+	case reflect.Float32, reflect.Float64:
+		return compareFloats(a.Float(), b.Float())
+	case reflect.Complex64, reflect.Complex128:
+		if c := compareFloats(real(a.Complex()), real(b.Complex())); c != 0 {
+			return c
+		}
+		return compareFloats(imag(a.Complex()), imag(b.Complex()))
+	case reflect.Bool:
+		switch {
+		case a.Bool() == b.Bool():
+			return 0
+		case a.Bool():
+			return 1
+		default:
+			return -1
+		}
+	case reflect.Pointer, reflect.UnsafePointer, reflect.Chan:
+		return compareOrdered(a.Pointer(), b.Pointer())
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if c := compareKeys(a.Field(i), b.Field(i)); c != 0 {
+				return c
+			}
+		}
+		return 0
+	case reflect.Array:
+		for i := 0; i < a.Len(); i++ {
+			if c := compareKeys(a.Index(i), b.Index(i)); c != 0 {
+				return c
+			}
+		}
+		return 0
+	case reflect.Interface:
+		switch {
+		case a.IsNil() && b.IsNil():
+			return 0
+		case a.IsNil():
+			return -1
+		case b.IsNil():
+			return 1
+		}
+		if c := compareKeys(reflect.ValueOf(a.Elem().Type()), reflect.ValueOf(b.Elem().Type())); c != 0 {
+			return c
+		}
+		return compareKeys(a.Elem(), b.Elem())
+	default:
+		return 0
+	}
+}
+
+// compareOrdered returns -1, 0 or 1 as a is less than, equal to or greater
+// than b.
+func compareOrdered[T int64 | uint64 | uintptr | float64 | string](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// compareFloats orders floats with NaN first, as fmt does.
+func compareFloats(a, b float64) int {
+	switch {
+	case a != a:
+		return -1
+	case b != b:
+		return 1
+	default:
+		return compareOrdered(a, b)
+	}
+}
+
+// appendSeparator appends the separator between the parts of a composite,
+// which is a comma in Go syntax.
+func appendSeparator(dst []byte, mode valueMode) []byte {
+	if mode == goSyntaxValue {
+		return append(dst, ", "...)
+	}
+	return append(dst, ' ')
+}
+
+// appendAddress appends the address held by a pointer, channel, function or
+// unsafe.Pointer as 0x followed by hex digits, or <nil> if it is nil. In Go
+// syntax it is converted to its type, as in (*T)(0x1234) or (*T)(nil).
+func appendAddress(dst []byte, v reflect.Value, mode valueMode) []byte {
+	address := v.Pointer()
+	if mode == goSyntaxValue {
+		dst = append(dst, '(')
+		dst = append(dst, v.Type().String()...)
+		dst = append(dst, ")("...)
+		if address == 0 {
+			dst = append(dst, "nil"...)
+		} else {
+			dst = appendInteger(dst, uint64(address), false, 'x', defaultSpec)
+		}
+		return append(dst, ')')
+	}
+	if address == 0 {
+		return append(dst, "<nil>"...)
+	}
+	return appendInteger(dst, uint64(address), false, 'x', defaultSpec)
+}
@@ -0,0 +1,427 @@
+//go:build !tinyfmt_noreflect
+
+// =============================================================================
+// Project: tinyfmt
+// File: reflect_test.go
+// Description: Test suite for reflection-based formatting in tinyfmt package.
+// Datasheet/Docs:
+//
+// Author: Jason Duffy
+// Created on: 18/10/2026
+//
+// Copyright: (C) 2026, Jason Duffy
+// License: See LICENSE file in the project root for full license information.
+// Disclaimer: See DISCLAIMER file in the project root for full disclaimer.
+// =============================================================================
+
+// -------------------------------------------------------------------------- //
+//                               Import Statement                             //
+// -------------------------------------------------------------------------- //
+
+package tinyfmt
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// -------------------------------------------------------------------------- //
+//               Private Consts, Structs & Variable Definitions               //
+// -------------------------------------------------------------------------- //
+
+type testRegister uint16
+
+type testCelsius float32
+
+type testLabel string
+
+type testExample struct {
+	Name  string
+	Value int
+}
+
+type testDevice struct {
+	Name  string
+	State testState
+}
+
+type testSensor struct {
+	Name     string
+	id       int
+	gain     float32
+	enabled  bool
+	states   []testState
+	limits   [2]int8
+	labels   map[string]uint
+	calib    testExample
+	last     interface{}
+	readings []interface{}
+}
+
+type testNested struct {
+	Label    string
+	Count    uint
+	Ratio    float64
+	Inner    testExample
+	Pointer  *testExample
+	Missing  *int
+	Values   []interface{}
+	Bytes    []byte
+	Err      error
+	Any      interface{}
+	register testRegister
+}
+
+type testNode struct {
+	Name     string
+	Children []interface{}
+}
+
+type testLocked struct {
+	mu    sync.Mutex
+	count int
+}
+
+// -------------------------------------------------------------------------- //
+//                              Public Functions                              //
+// -------------------------------------------------------------------------- //
+
+func TestSprintReflect(t *testing.T) {
+	testCases := []struct {
+		arguments []interface{}
+		want      string
+	}{
+		{[]interface{}{map[string]int{"key": 1}}, "{key:1}"},          // Test formatting map
+		{[]interface{}{testExample{"example", 123}}, "{example 123}"}, // Test formatting struct
+		{[]interface{}{testRegister(0x1234)}, "4660"},                 // Test named integer type
+		{[]interface{}{testLabel("id"), 7, 8}, "id7 8"},               // Test named string type counts as a string
+	}
+
+	for _, testCase := range testCases {
+		got := Sprint(testCase.arguments...)
+		if got != testCase.want {
+			t.Errorf("Sprint(%v) = %q, want %q", testCase.arguments, got, testCase.want)
+		}
+	}
+
+	if got := Sprintln(testLabel("id"), 7); got != "id 7\n" {
+		t.Errorf("Sprintln(testLabel, 7) = %q, want %q", got, "id 7\n")
+	}
+}
+
+func TestSprintfReflect(t *testing.T) {
+	var nilID *testID
+
+	testCases := []struct {
+		format    string
+		arguments []interface{}
+		want      string
+	}{
+		{"Map: %v", []interface{}{map[string]int{"key": 1}}, "Map: {key:1}"},                            // Test formatting map
+		{"Struct: %v", []interface{}{testExample{"example", 123}}, "Struct: {example 123}"},             // Test formatting struct
+		{"Struct: %+v", []interface{}{testExample{"example", 123}}, "Struct: {Name:example Value:123}"}, // Test formatting struct with field names
+		{"%x", []interface{}{testRegister(0xa5)}, "0xa5"},                                               // Test named integer type
+		{"%v", []interface{}{testRegister(7)}, "7"},                                                     // Test named integer type with %v
+		{"%.1f", []interface{}{testCelsius(21.5)}, "21.5"},                                              // Test named float type
+		{"%v", []interface{}{testDevice{"pump", 1}}, "{pump running}"},                                  // Test Stringer inside struct
+		{"%v", []interface{}{[]testState{0, 1}}, "[idle running]"},                                      // Test Stringer inside slice
+		{"%s", []interface{}{nilID}, "<nil>"},                                                           // Test nil pointer receiver
+		{"%d", []interface{}{testState(1)}, "1"},                                                        // Test %d ignores Stringer
+		{"%.2v", []interface{}{struct{ A, B float64 }{1.25, 2.5}}, "{1.25 2.5}"},                        // Test precision does not truncate structs
+		{"%08v|", []interface{}{struct{ A, B int }{1, 2}}, "   {1 2}|"},                                 // Test composites are padded with spaces
+		{"%.1v|", []interface{}{[]string{"abc"}}, "[abc]|"},                                             // Test precision does not truncate slices
+		{"%.3v", []interface{}{testState(1)}, "run"},                                                    // Test precision truncates String output
+	}
+
+	for _, testCase := range testCases {
+		got, err := Sprintf(testCase.format, testCase.arguments...)
+		if err != nil {
+			t.Errorf("Sprintf(%q, %v) unexpected error: %v", testCase.format, testCase.arguments, err)
+			continue
+		}
+		if got != testCase.want {
+			t.Errorf("Sprintf(%q, %v) = %q, want %q", testCase.format, testCase.arguments, got, testCase.want)
+		}
+	}
+
+	if err := Errorf("Unsupported type: %v", map[string]int{"key": 1}); err.Error() != "Unsupported type: {key:1}" {
+		t.Errorf("Errorf with a map = %q, want %q", err.Error(), "Unsupported type: {key:1}")
+	}
+}
+
+func TestSprintUnexportedFields(t *testing.T) {
+	sensor := testSensor{
+		Name:     "adc0",
+		id:       -3,
+		gain:     1.5,
+		enabled:  true,
+		states:   []testState{0, 1},
+		limits:   [2]int8{-1, 1},
+		labels:   map[string]uint{"ch": 2},
+		calib:    testExample{"offset", 4},
+		last:     testState(1),
+		readings: []interface{}{1, "two", testExample{"x", 3}},
+	}
+
+	sensorWant := "{Name:adc0 id:-3 gain:1.5 enabled:true states:[0 1] limits:[-1 1] labels:{ch:2} " +
+		"calib:{Name:offset Value:4} last:1 readings:[1 two {Name:x Value:3}]}"
+
+	testCases := []struct {
+		value interface{}
+		want  string
+	}{
+		{sensor, sensorWant},                                                          // Test unexported fields of every kind
+		{[]testSensor{sensor}, "[" + sensorWant + "]"},                                // Test unexported fields inside a slice
+		{testExample{"public", 1}, "{Name:public Value:1}"},                           // Test exported fields are unchanged
+		{testDevice{"pump", 1}, "{Name:pump State:running}"},                          // Test exported fields still use String
+		{struct{ state testState }{1}, "{state:1}"},                                   // Test unexported fields do not call String
+		{struct{ err error }{nil}, "{err:<nil>}"},                                     // Test nil unexported interface
+		{struct{ m map[int][]testState }{map[int][]testState{1: {0}}}, "{m:{1:[0]}}"}, // Test nested unexported composites
+	}
+
+	for _, testCase := range testCases {
+		if got, err := Sprintf("%+v", testCase.value); err != nil || got != testCase.want {
+			t.Errorf("Sprintf(%%+v, %#v) = (%q, %v), want %q", testCase.value, got, err, testCase.want)
+		}
+	}
+
+	// A struct holding a mutex must not panic, whatever the mutex's layout
+	locked := []testLocked{{count: 2}}
+	locked[0].mu.Lock()
+	for _, format := range []string{"%v", "%+v"} {
+		if got, err := Sprintf(format, locked); err != nil || got != fmt.Sprintf(format, locked) {
+			t.Errorf("Sprintf(%q, []testLocked) = (%q, %v), want %q", format, got, err, fmt.Sprintf(format, locked))
+		}
+	}
+	locked[0].mu.Unlock()
+}
+
+func TestSprintfValueModes(t *testing.T) {
+	example := &testExample{"x", 1}
+	nested := testNested{
+		Label:    "adc",
+		Count:    3,
+		Ratio:    1e6,
+		Inner:    testExample{"in", -2},
+		Pointer:  example,
+		Values:   []interface{}{1, "a", nil, uint8(2), 0.5, []int{4}},
+		Bytes:    []byte("hi"),
+		register: 9,
+	}
+	var nilFunc func()
+	var nilChan chan int
+
+	values := []interface{}{
+		nested,                               // Test fields of every kind
+		&nested,                              // Test pointer to struct
+		[]testNested{nested},                 // Test structs inside a slice
+		struct{}{},                           // Test empty anonymous struct
+		struct{ E error }{},                  // Test nil interface field
+		[]byte("hi"),                         // Test byte slice
+		[]byte(nil),                          // Test nil byte slice
+		[3]uint8{1, 2, 3},                    // Test byte array
+		[][]int{{1}, nil},                    // Test nested and nil slices
+		&[]int{1},                            // Test pointer to slice
+		testRegister(3),                      // Test named unsigned integer
+		testLabel("id"),                      // Test named string
+		[]testPin{1, 2},                      // Test GoString inside a slice
+		"quote\"d",                           // Test string
+		-5, uint16(5), uintptr(9), 2.5, 1e21, // Test scalars
+		true, 1 + 2i, nilFunc, nilChan,
+	}
+
+	for _, value := range values {
+		for _, format := range []string{"%v", "%+v", "%#v"} {
+			want := fmt.Sprintf(format, value)
+			if got, err := Sprintf(format, value); err != nil || got != want {
+				t.Errorf("Sprintf(%q, %T) = (%q, %v), want %q", format, value, got, err, want)
+			}
+		}
+	}
+
+	for _, testCase := range []struct {
+		format string
+		value  interface{}
+	}{
+		{"%+5v|", 2},                        // Test plus is not a sign for %v
+		{"%+v", -2},                         // Test negative numbers keep their sign
+		{"%#5v|", "ab"},                     // Test width with a quoted string
+		{"%#-8v|", 1},                       // Test left-aligned Go syntax
+		{"%#v", testError{3}},               // Test Go syntax ignores Error
+		{"%#v", testDevice{"p", 1}},         // Test Go syntax ignores String
+		{"%#v", &testID{"d", 1}},            // Test Go syntax of a pointer with String
+		{"%+v", testDevice{"p", 1}},         // Test field names with String
+		{"%#v", map[string][]int{"k": {1}}}, // Test map in Go syntax
+		{"%#v", map[string]int(nil)},        // Test nil map in Go syntax
+	} {
+		want := fmt.Sprintf(testCase.format, testCase.value)
+		if got, err := Sprintf(testCase.format, testCase.value); err != nil || got != want {
+			t.Errorf("Sprintf(%q, %v) = (%q, %v), want %q", testCase.format, testCase.value, got, err, want)
+		}
+	}
+}
+
+func TestSprintSortedMaps(t *testing.T) {
+	type key struct {
+		A int
+		B string
+	}
+	first, second := new(int), new(int)
+	nan := math.NaN()
+
+	testCases := []struct {
+		value interface{}
+		want  string
+	}{
+		{map[int]string{3: "c", -1: "a", 20: "d", 2: "b"}, "{-1:a 2:b 3:c 20:d}"},             // Test integer keys sort numerically
+		{map[uint8]bool{200: true, 7: false}, "{7:false 200:true}"},                           // Test unsigned keys
+		{map[string]int{"b": 2, "a": 1, "B": 3, "": 0}, "{:0 B:3 a:1 b:2}"},                   // Test string keys sort by bytes
+		{map[float64]int{2.5: 1, nan: 2, -1: 3, math.Inf(1): 4}, "{NaN:2 -1:3 2.5:1 +Inf:4}"}, // Test NaN sorts first
+		{map[bool]int{true: 1, false: 0}, "{false:0 true:1}"},                                 // Test false sorts first
+		{map[complex128]int{2 + 1i: 1, 1 + 2i: 2, 1 + 1i: 3}, "{(1+1i):3 (1+2i):2 (2+1i):1}"}, // Test complex keys by real then imaginary part
+		{map[key]int{{2, "a"}: 1, {1, "b"}: 2, {1, "a"}: 3}, "{{1 a}:3 {1 b}:2 {2 a}:1}"},     // Test struct keys field by field
+		{map[[2]int]int{{1, 2}: 1, {0, 9}: 2}, "{[0 9]:2 [1 2]:1}"},                           // Test array keys element by element
+		{map[interface{}]int{nil: 0, 2: 2, 1: 1}, "{<nil>:0 1:1 2:2}"},                        // Test nil interface key sorts first
+		{map[testState]int{1: 1, 0: 0}, "{idle:0 running:1}"},                                 // Test keys sort by value, not String
+		{struct{ m map[string]int }{map[string]int{"z": 26, "a": 1}}, "{{a:1 z:26}}"},         // Test unexported map field
+	}
+
+	for _, testCase := range testCases {
+		for i := 0; i < 10; i++ {
+			if got := Sprint(testCase.value); got != testCase.want {
+				t.Errorf("Sprint(%v) = %q, want %q", testCase.value, got, testCase.want)
+				break
+			}
+		}
+	}
+
+	// Go syntax prints maps as fmt does, so keys of any type can be checked
+	for _, value := range []interface{}{
+		map[*int]string{first: "first", second: "second"},
+		map[interface{}]string{"a": "string", 1: "int", 2.5: "float", true: "bool", "b": "string"},
+		map[string][]int{"b": {2}, "a": {1}},
+	} {
+		want := fmt.Sprintf("%#v", value)
+		if got, err := Sprintf("%#v", value); err != nil || got != want {
+			t.Errorf("Sprintf(%%#v, %T) = (%q, %v), want %q", value, got, err, want)
+		}
+	}
+}
+
+func TestSprintCyclesAndDepth(t *testing.T) {
+	cyclicSlice := []interface{}{1, nil}
+	cyclicSlice[1] = cyclicSlice
+	cyclicMap := map[string]interface{}{"a": 1}
+	cyclicMap["self"] = cyclicMap
+	node := testNode{"root", make([]interface{}, 1)}
+	node.Children[0] = node
+	shared := []int{1}
+	prefixSlice := []interface{}{1, nil}
+	prefixSlice[1] = prefixSlice[:1]
+	deep := []interface{}{0}
+	for i := 0; i < 20; i++ {
+		deep = []interface{}{deep}
+	}
+	deepWant := strings.Repeat("[", 16) + "..." + strings.Repeat("]", 16)
+
+	testCases := []struct {
+		format string
+		value  interface{}
+		want   string
+	}{
+		{"%v", cyclicSlice, "[1 <cycle>]"},                                   // Test slice that contains itself
+		{"%v", &cyclicSlice, "&[1 <cycle>]"},                                 // Test pointer to slice that contains itself
+		{"%v", cyclicMap, "{a:1 self:<cycle>}"},                              // Test map that contains itself
+		{"%+v", node, "{Name:root Children:[{Name:root Children:<cycle>}]}"}, // Test struct reached again through its slice
+		{"%#v", cyclicSlice, "[]interface {}{1, <cycle>}"},                   // Test cycle in Go syntax
+		{"%v", []interface{}{shared, shared}, "[[1] [1]]"},                   // Test shared slices are not cycles
+		{"%v", prefixSlice, "[1 [1]]"},                                       // Test a prefix of a slice is not a cycle
+		{"%v", deep, deepWant},                                               // Test default depth limit
+	}
+
+	for _, testCase := range testCases {
+		got, err := Sprintf(testCase.format, testCase.value)
+		if err != nil || got != testCase.want {
+			t.Errorf("Sprintf(%q, %T) = (%q, %v), want %q", testCase.format, testCase.value, got, err, testCase.want)
+		}
+	}
+
+	defer SetMaxDepth(0)
+	SetMaxDepth(2)
+	nested := []interface{}{1, []interface{}{2, []interface{}{3}}}
+	if got := Sprint(nested); got != "[1 [2 ...]]" {
+		t.Errorf("Sprint(nested) with depth 2 = %q, want %q", got, "[1 [2 ...]]")
+	}
+	SetMaxDepth(1)
+	if got := Sprint(testNode{"a", []interface{}{1}}); got != "{a ...}" {
+		t.Errorf("Sprint(testNode) with depth 1 = %q, want %q", got, "{a ...}")
+	}
+	SetMaxDepth(0)
+	if got := Sprint(deep); got != deepWant {
+		t.Errorf("Sprint(deep) after reset = %q, want %q", got, deepWant)
+	}
+}
+
+func TestSprintPointersAndNil(t *testing.T) {
+	example := &testExample{"x", 1}
+	number := 42
+	fixed := testFixed(256)
+	var nilExample *testExample
+	var nilMap map[string]int
+	channel := make(chan int)
+	pointers := struct {
+		Number  *int
+		Example *testExample
+		Missing *testExample
+	}{&number, example, nil}
+	values := []interface{}{nil, 1, &number, example, []int{2}}
+
+	testCases := []struct {
+		format string
+		value  interface{}
+		want   string
+	}{
+		{"%v", nil, "<nil>"},                                       // Test nil
+		{"%v", nilExample, "<nil>"},                                // Test nil pointer
+		{"%v", example, "&{x 1}"},                                  // Test pointer to struct
+		{"%v", &[]int{1, 2}, "&[1 2]"},                             // Test pointer to slice
+		{"%v", &[2]bool{true}, "&[true false]"},                    // Test pointer to array
+		{"%v", &map[string]int{"a": 1}, "&{a:1}"},                  // Test pointer to map
+		{"%v", &number, fmt.Sprintf("%v", &number)},                // Test pointer to scalar is an address
+		{"%v", &example, fmt.Sprintf("%v", &example)},              // Test pointer to pointer is an address
+		{"%v", channel, fmt.Sprintf("%v", channel)},                // Test channel is an address
+		{"%v", pointers, fmt.Sprintf("%v", pointers)},              // Test pointer fields are addresses
+		{"%v", values, fmt.Sprintf("%v", values)},                  // Test interface elements use their dynamic values
+		{"%v", map[string]interface{}{"n": nil}, "{n:<nil>}"},      // Test nil interface map value
+		{"%v", []error{nil, testError{3}}, "[<nil> error code 3]"}, // Test interface elements keep their methods
+		{"%v", []*testID{{"dev", 7}, nil}, "[dev-7 <nil>]"},        // Test pointer elements keep their methods
+		{"%p", example, fmt.Sprintf("%p", example)},                // Test pointer address
+		{"%#p", example, fmt.Sprintf("%#p", example)},              // Test pointer address without 0x
+		{"%p", nilExample, "0x0"},                                  // Test nil pointer address
+		{"%p", nilMap, "0x0"},                                      // Test nil map address
+		{"%p", channel, fmt.Sprintf("%p", channel)},                // Test channel address
+		{"%p", values, fmt.Sprintf("%p", values)},                  // Test slice address
+		{"%p", &fixed, fmt.Sprintf("%p", &fixed)},                  // Test %p ignores Formatter
+	}
+
+	for _, testCase := range testCases {
+		got, err := Sprintf(testCase.format, testCase.value)
+		if err != nil {
+			t.Errorf("Sprintf(%q, %v) unexpected error: %v", testCase.format, testCase.value, err)
+			continue
+		}
+		if got != testCase.want {
+			t.Errorf("Sprintf(%q, %v) = %q, want %q", testCase.format, testCase.value, got, testCase.want)
+		}
+	}
+
+	if got := Sprint(nil, nilExample); got != "<nil> <nil>" {
+		t.Errorf("Sprint(nil, nilExample) = %q, want %q", got, "<nil> <nil>")
+	}
+	if _, err := Sprintf("%p", 42); err == nil || err.Error() != "argument for %p is not a pointer" {
+		t.Errorf("Sprintf(%%p, 42) error = %v, want a pointer error", err)
+	}
+}
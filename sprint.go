@@ -19,246 +19,204 @@
 package tinyfmt
 
 import (
-	"errors"
-	"reflect"
+	"sync/atomic"
 
 	"github.com/Jason-Duffy/tinystrconv"
 )
 
+// -------------------------------------------------------------------------- //
+//               Private Consts, Structs & Variable Definitions               //
+// -------------------------------------------------------------------------- //
+
+// valueMode selects how %v prints structs and other composites.
+type valueMode int
+
+const (
+	plainValue    valueMode = iota // %v: {value value}
+	namedValue                     // %+v: {Name:value Name:value}
+	goSyntaxValue                  // %#v: pkg.Type{Name:value, Name:value}
+)
+
+// defaultMaxDepth is how deeply composites are walked unless SetMaxDepth says
+// otherwise. Each level takes a few stack frames, which small goroutine
+// stacks can only afford so many of.
+const defaultMaxDepth = 16
+
+// maxDepth is the depth set by SetMaxDepth, or zero for the default.
+var maxDepth atomic.Int64
+
 // -------------------------------------------------------------------------- //
 //                              Public Functions                              //
 // -------------------------------------------------------------------------- //
 
+// SetMaxDepth sets how many levels of nested structs, slices, arrays and maps
+// Sprint and %v print; anything nested deeper prints as ... instead. A depth
+// of zero or less restores the default of 16. It is safe to call while other
+// goroutines are formatting.
+func SetMaxDepth(depth int) {
+	if depth < 0 {
+		depth = 0
+	}
+	maxDepth.Store(int64(depth))
+}
+
 // Sprint concatenates the string representations of the provided arguments.
+// As with fmt, a space is added between operands when neither is a string.
 func Sprint(arguments ...interface{}) string {
-	var result string
-	for _, argument := range arguments {
-		switch value := argument.(type) {
-		case string:
-			result += value
-		case int:
-			str, _ := tinystrconv.IntToString(value, 10)
-			result += str
-		case bool:
-			result += tinystrconv.BoolToString(value)
-		case float64:
-			str, _ := tinystrconv.FloatToString(value, -1) // Use -1 for full precision
-			result += str
-		default:
-			result += formatUnsupported(value)
-		}
-	}
+	p := newPrinter()
+	p.print(arguments)
+	result := string(p.buf)
+	p.free()
+	return result
+}
+
+// Sprintln formats the provided arguments with spaces always added between
+// them and a newline appended.
+func Sprintln(arguments ...interface{}) string {
+	p := newPrinter()
+	p.println(arguments)
+	result := string(p.buf)
+	p.free()
 	return result
 }
 
 // Sprintf formats the provided arguments according to the format specifier.
 func Sprintf(format string, arguments ...interface{}) (string, error) {
-	var result []byte
-	argIndex := 0
-
-	for i := 0; i < len(format); i++ {
-		if format[i] == '%' {
-			if i+1 < len(format) {
-				i++
-
-				// Handle precision for floats (e.g., "%.2f")
-				precision := -1
-				if format[i] == '.' {
-					i++
-					start := i
-					for i < len(format) && format[i] >= '0' && format[i] <= '9' {
-						i++
-					}
-					if start < i {
-						precision = 0
-						for j := start; j < i; j++ {
-							precision = precision*10 + int(format[j]-'0')
-						}
-					}
-				}
-
-				// Handle different format specifiers
-				switch format[i] {
-				case 'd':
-					if argIndex >= len(arguments) {
-						return "", errors.New("missing argument for %d")
-					}
-					intVal, ok := arguments[argIndex].(int)
-					if !ok {
-						return "", errors.New("argument for %d is not an int")
-					}
-					str, err := tinystrconv.IntToString(intVal, 10)
-					if err != nil {
-						return "", err
-					}
-					result = append(result, []byte(str)...)
-					argIndex++
-				case 'f':
-					if argIndex >= len(arguments) {
-						return "", errors.New("missing argument for %f")
-					}
-					floatVal, ok := arguments[argIndex].(float64)
-					if !ok {
-						return "", errors.New("argument for %f is not a float64")
-					}
-					str, err := tinystrconv.FloatToString(floatVal, precision)
-					if err != nil {
-						return "", err
-					}
-					result = append(result, []byte(str)...)
-					argIndex++
-				case 'b':
-					if argIndex >= len(arguments) {
-						return "", errors.New("missing argument for %b")
-					}
-					intVal, ok := arguments[argIndex].(int)
-					if !ok {
-						return "", errors.New("argument for %b is not an int")
-					}
-					str, err := tinystrconv.IntToString(intVal, 2)
-					if err != nil {
-						return "", err
-					}
-					result = append(result, []byte(str)...)
-					argIndex++
-				case 'x':
-					if argIndex >= len(arguments) {
-						return "", errors.New("missing argument for %x")
-					}
-					intVal, ok := arguments[argIndex].(int)
-					if !ok {
-						return "", errors.New("argument for %x is not an int")
-					}
-					str, err := tinystrconv.IntToString(intVal, 16)
-					if err != nil {
-						return "", err
-					}
-					result = append(result, []byte(str)...)
-					argIndex++
-				case 'o':
-					if argIndex >= len(arguments) {
-						return "", errors.New("missing argument for %o")
-					}
-					intVal, ok := arguments[argIndex].(int)
-					if !ok {
-						return "", errors.New("argument for %o is not an int")
-					}
-					str, err := tinystrconv.IntToString(intVal, 8)
-					if err != nil {
-						return "", err
-					}
-					result = append(result, []byte(str)...)
-					argIndex++
-				case 'v':
-					if argIndex >= len(arguments) {
-						return "", errors.New("missing argument for %v")
-					}
-					str, err := formatValue(arguments[argIndex])
-					if err != nil {
-						return "", err
-					}
-					result = append(result, []byte(str)...)
-					argIndex++
-				case 's':
-					if argIndex >= len(arguments) {
-						return "", errors.New("missing argument for %s")
-					}
-					strVal, ok := arguments[argIndex].(string)
-					if !ok {
-						return "", errors.New("argument for %s is not a string")
-					}
-					result = append(result, []byte(strVal)...)
-					argIndex++
-				case '%':
-					result = append(result, '%')
-				default:
-					return "", errors.New("unsupported format specifier")
-				}
-			} else {
-				return "", errors.New("incomplete format specifier at end of string")
-			}
-		} else {
-			result = append(result, format[i])
-		}
+	p := newPrinter()
+	defer p.free()
+	if err := p.printf(format, arguments); err != nil {
+		return "", err
 	}
+	return string(p.buf), nil
+}
 
-	return string(result), nil
+// SprintfLenient formats like Sprintf but never fails. As with fmt, a bad directive is written
+// inline as a marker, such as %!d(string=hi), %!s(MISSING) or %!(EXTRA int=3), and formatting
+// carries on with the rest of the string.
+func SprintfLenient(format string, arguments ...interface{}) string {
+	p := newPrinter()
+	p.lenient = true
+	p.printf(format, arguments)
+	result := string(p.buf)
+	p.free()
+	return result
 }
 
 // -------------------------------------------------------------------------- //
 //                             Private Functions                              //
 // -------------------------------------------------------------------------- //
 
-// formatUnsupported handles the formatting of unsupported types.
-func formatUnsupported(value interface{}) string {
-	v := reflect.ValueOf(value)
-	switch v.Kind() {
-	case reflect.Struct:
-		return formatStruct(v)
-	case reflect.Slice, reflect.Array:
-		return formatSlice(v)
-	case reflect.Map:
-		return formatMap(v)
-	default:
-		return "<unsupported>"
+// appendValue handles the formatting of %v format specifier. As with fmt, the
+// '+' and '#' flags choose how composites print rather than adding a sign or
+// an alternate form, so they are cleared once read; a Formatter still sees
+// them.
+func appendValue(dst []byte, value interface{}, spec formatSpec) []byte {
+	flags := spec
+	mode := plainValue
+	if spec.sharp {
+		mode = goSyntaxValue
+	} else if spec.plus {
+		mode = namedValue
+	}
+	spec.plus, spec.sharp = false, false
+	if mode == goSyntaxValue {
+		return appendGoSyntax(dst, value, flags, spec)
+	}
+
+	if out, ok := appendScalar(dst, value, 'v', spec); ok {
+		return out
+	}
+	if formatter, ok := value.(Formatter); ok {
+		return appendFormatter(dst, formatter, 'v', flags)
+	}
+	if str, ok := handleMethods(value, 'v'); ok {
+		return appendString(dst, str, spec)
 	}
+	return appendDump(dst, formatUnsupported(value, mode), spec)
 }
 
-// formatValue handles the formatting of %v format specifier.
-func formatValue(value interface{}) (string, error) {
+// appendGoSyntax handles %#v, which prints a value as it would be written in
+// Go source: strings are quoted, unsigned integers are hex and composites are
+// prefixed with their type. A GoString method is used instead of Error and
+// String.
+func appendGoSyntax(dst []byte, value interface{}, flags, spec formatSpec) []byte {
 	switch value := value.(type) {
+	case nil:
+		return appendWhole(dst, "<nil>", spec)
 	case bool:
-		return tinystrconv.BoolToString(value), nil
+		return appendWhole(dst, tinystrconv.BoolToString(value), spec)
 	case string:
-		return value, nil
-	case int:
-		return tinystrconv.IntToString(value, 10)
+		return appendQuotedString(dst, value, spec)
+	case int, int8, int16, int32, int64:
+		magnitude, negative, _ := integerArgument(value)
+		return appendInteger(dst, magnitude, negative, 'd', spec)
+	case uint, uint8, uint16, uint32, uint64, uintptr:
+		magnitude, _, _ := integerArgument(value)
+		return appendInteger(dst, magnitude, false, 'x', spec)
+	case float32:
+		return appendFloat(dst, float64(value), 32, 'g', spec)
 	case float64:
-		return tinystrconv.FloatToString(value, -1)
+		return appendFloat(dst, value, 64, 'g', spec)
+	case complex64:
+		return appendComplex(dst, complex128(value), 32, 'g', spec)
+	case complex128:
+		return appendComplex(dst, value, 64, 'g', spec)
+	case []byte:
+		return appendDump(dst, string(appendGoBytes(nil, value)), spec)
 	default:
-		return formatUnsupported(value), nil
+		if formatter, ok := value.(Formatter); ok {
+			return appendFormatter(dst, formatter, 'v', flags)
+		}
+		if str, ok := handleGoString(value); ok {
+			return appendString(dst, str, spec)
+		}
+		return appendDump(dst, formatUnsupported(value, goSyntaxValue), spec)
 	}
 }
 
-// formatStruct formats a struct as a string.
-func formatStruct(v reflect.Value) string {
-	result := "{"
-	for i := 0; i < v.NumField(); i++ {
-		if i > 0 {
-			result += " "
-		}
-		field := v.Type().Field(i).Name
-		value := v.Field(i).Interface()
-		result += field + ":" + Sprint(value)
-	}
-	result += "}"
-	return result
+// appendWhole appends text that the precision must not cut short, such as
+// true or <nil>, padded to the width.
+func appendWhole(dst []byte, str string, spec formatSpec) []byte {
+	spec.precision = -1
+	return appendString(dst, str, spec)
+}
+
+// appendDump appends a composite printed by formatUnsupported. The precision
+// and '0' flag are meant for its scalars, which are printed without them, so
+// only the width is applied, padding the whole dump with spaces.
+func appendDump(dst []byte, str string, spec formatSpec) []byte {
+	spec.precision = -1
+	spec.zero = false
+	return appendString(dst, str, spec)
 }
 
-// formatSlice formats a slice or array as a string.
-func formatSlice(v reflect.Value) string {
-	result := "["
-	for i := 0; i < v.Len(); i++ {
+// appendGoBytes appends a []byte as []byte{0x1, 0x2}, or []byte(nil).
+func appendGoBytes(dst []byte, value []byte) []byte {
+	if value == nil {
+		return append(dst, "[]byte(nil)"...)
+	}
+	dst = append(dst, "[]byte{"...)
+	for i, b := range value {
 		if i > 0 {
-			result += " "
+			dst = append(dst, ", "...)
 		}
-		result += Sprint(v.Index(i).Interface())
+		dst = appendInteger(dst, uint64(b), false, 'x', defaultSpec)
 	}
-	result += "]"
-	return result
+	return append(dst, '}')
 }
 
-// formatMap formats a map as a string.
-func formatMap(v reflect.Value) string {
-	result := "{"
-	keys := v.MapKeys()
-	for i, key := range keys {
-		if i > 0 {
-			result += " "
-		}
-		value := v.MapIndex(key).Interface()
-		result += Sprint(key.Interface()) + ":" + Sprint(value)
+// currentMaxDepth returns the depth set by SetMaxDepth or the default.
+func currentMaxDepth() int {
+	if depth := maxDepth.Load(); depth > 0 {
+		return int(depth)
 	}
-	result += "}"
-	return result
+	return defaultMaxDepth
+}
+
+// spec returns the flags a nested Formatter is called with in this mode.
+func (mode valueMode) spec() formatSpec {
+	spec := defaultSpec
+	spec.plus = mode == namedValue
+	spec.sharp = mode == goSyntaxValue
+	return spec
 }
@@ -28,11 +28,6 @@ import (
 // -------------------------------------------------------------------------- //
 
 func TestSprint(t *testing.T) {
-	type ExampleStruct struct {
-		Name  string
-		Value int
-	}
-
 	testCases := []struct {
 		arguments []interface{}
 		want      string
@@ -40,16 +35,15 @@ func TestSprint(t *testing.T) {
 		{[]interface{}{"Hello, ", "world!"}, "Hello, world!"},                                     // Test concatenating strings
 		{[]interface{}{"Value: ", 42}, "Value: 42"},                                               // Test concatenating string and integer
 		{[]interface{}{"Bool: ", true}, "Bool: true"},                                             // Test concatenating string and boolean
-		{[]interface{}{"Float: ", 3.14159}, "Float: 3.141590000000000"},                           // Test concatenating string and float
+		{[]interface{}{"Float: ", 3.14159}, "Float: 3.14159"},                                     // Test concatenating string and float
 		{[]interface{}{"Mixed: ", "string", ", ", 123, ", ", false}, "Mixed: string, 123, false"}, // Test concatenating mixed types
 		{[]interface{}{"Empty: ", ""}, "Empty: "},                                                 // Test concatenating with empty string
-		{[]interface{}{nil}, "<unsupported>"},                                                     // Test unsupported type (nil)
-		{[]interface{}{1, 2, 3, 4, 5}, "12345"},                                                   // Test concatenating multiple integers
+		{[]interface{}{nil}, "<nil>"},                                                             // Test nil
+		{[]interface{}{1, 2, 3, 4, 5}, "1 2 3 4 5"},                                               // Test spaces between non-string operands
+		{[]interface{}{"a", 1, 2, "b", "c", 3.5}, "a1 2bc3.5"},                                    // Test no space next to a string
 		{[]interface{}{"Multiple: ", true, ", ", 1, ", ", "text"}, "Multiple: true, 1, text"},     // Test multiple different types
 		{[]interface{}{math.MaxInt64}, "9223372036854775807"},                                     // Test edge case for maximum integer value
 		{[]interface{}{[]int{1, 2, 3}}, "[1 2 3]"},                                                // Test formatting slice
-		{[]interface{}{map[string]int{"key": 1}}, "{key:1}"},                                      // Test formatting map
-		{[]interface{}{ExampleStruct{"example", 123}}, "{Name:example Value:123}"},                // Test formatting struct
 	}
 
 	for _, testCase := range testCases {
@@ -60,37 +54,175 @@ func TestSprint(t *testing.T) {
 	}
 }
 
+func TestSprintln(t *testing.T) {
+	testCases := []struct {
+		arguments []interface{}
+		want      string
+	}{
+		{[]interface{}{}, "\n"},                                 // Test no arguments
+		{[]interface{}{"Hello,", "world!"}, "Hello, world!\n"},  // Test strings are separated
+		{[]interface{}{"Value:", 42, true}, "Value: 42 true\n"}, // Test mixed types are separated
+		{[]interface{}{1.5, []int{1, 2}}, "1.5 [1 2]\n"},        // Test composite operands
+	}
+
+	for _, testCase := range testCases {
+		got := Sprintln(testCase.arguments...)
+		if got != testCase.want {
+			t.Errorf("Sprintln(%v) = %q, want %q", testCase.arguments, got, testCase.want)
+		}
+	}
+}
+
 func TestSprintf(t *testing.T) {
-	type ExampleStruct struct {
-		Name  string
-		Value int
+	testCases := []struct {
+		format    string
+		arguments []interface{}
+		want      string
+		shouldErr bool
+	}{
+		{"Hello, %s!", []interface{}{"world"}, "Hello, world!", false},                               // Test formatting string
+		{"Value: %d", []interface{}{42}, "Value: 42", false},                                         // Test formatting integer
+		{"Hex: %x", []interface{}{255}, "Hex: 0xff", false},                                          // Test formatting hexadecimal
+		{"Binary: %b", []interface{}{7}, "Binary: 0b111", false},                                     // Test formatting binary
+		{"Octal: %o", []interface{}{64}, "Octal: 0o100", false},                                      // Test formatting octal
+		{"Float: %.2f", []interface{}{3.14159}, "Float: 3.14", false},                                // Test formatting float with precision
+		{"Float: %.5f", []interface{}{3.14159}, "Float: 3.14159", false},                             // Test formatting float with higher precision
+		{"Bool: %v", []interface{}{true}, "Bool: true", false},                                       // Test formatting boolean true
+		{"Bool: %v", []interface{}{false}, "Bool: false", false},                                     // Test formatting boolean false
+		{"Multiple: %d, %s, %v", []interface{}{42, "test", true}, "Multiple: 42, test, true", false}, // Test multiple format specifiers
+		{"Precision: %.0f", []interface{}{123.456}, "Precision: 123", false},                         // Test float with precision 0
+		{"Bytes: %s", []interface{}{[]byte("hi")}, "Bytes: hi", false},                               // Test formatting byte slice as string
+		{"Bytes: %.1s|%4s", []interface{}{[]byte("hi"), []byte("ok")}, "Bytes: h|  ok", false},       // Test byte slice with precision and width
+		{"Invalid: %z", []interface{}{42}, "", true},                                                 // Test unsupported format specifier
+		{"Missing arg: %d %d", []interface{}{42}, "", true},                                          // Test missing argument
+		{"Edge case: %d", []interface{}{math.MaxInt64}, "Edge case: 9223372036854775807", false},     // Test edge case for large integer
+		{"Negative: %d", []interface{}{-123}, "Negative: -123", false},                               // Test negative integer
+		{"Escape: %%", []interface{}{}, "Escape: %", false},                                          // Test escape percentage sign
+		{"Slice: %v", []interface{}{[]int{1, 2, 3}}, "Slice: [1 2 3]", false},                        // Test formatting slice
+		{"Extra: %d", []interface{}{1, 2}, "", true},                                                 // Test extra argument
+		{"No verbs", []interface{}{"forgotten"}, "", true},                                           // Test argument without directive
+	}
+
+	for _, testCase := range testCases {
+		got, err := Sprintf(testCase.format, testCase.arguments...)
+		if (err != nil) != testCase.shouldErr {
+			t.Errorf("Sprintf(%q, %v) error = %v, wantErr %v", testCase.format, testCase.arguments, err, testCase.shouldErr)
+			continue
+		}
+		if got != testCase.want {
+			t.Errorf("Sprintf(%q, %v) = %q, want %q", testCase.format, testCase.arguments, got, testCase.want)
+		}
+	}
+}
+
+func TestSprintfFlags(t *testing.T) {
+	testCases := []struct {
+		format    string
+		arguments []interface{}
+		want      string
+	}{
+		{"%5d|", []interface{}{42}, "   42|"},              // Test right-aligned width
+		{"%-5d|", []interface{}{42}, "42   |"},             // Test left-aligned width
+		{"%05d", []interface{}{-42}, "-0042"},              // Test zero padding after the sign
+		{"%+d", []interface{}{5}, "+5"},                    // Test forced plus sign
+		{"% d", []interface{}{5}, " 5"},                    // Test space for elided sign
+		{"%+05d", []interface{}{3}, "+0003"},               // Test plus sign with zero padding
+		{"%.3d", []interface{}{7}, "007"},                  // Test integer precision as minimum digits
+		{"%8.3d", []interface{}{7}, "     007"},            // Test precision overrides zero padding
+		{"%.0d", []interface{}{0}, ""},                     // Test precision zero with value zero
+		{"%+.0d", []interface{}{0}, ""},                    // Test precision zero drops the sign
+		{"% .0d", []interface{}{0}, ""},                    // Test precision zero drops the space
+		{"%#.0x", []interface{}{0}, ""},                    // Test precision zero drops the prefix
+		{"%#5.0x", []interface{}{0}, "     "},              // Test precision zero keeps the width
+		{"%-05d|", []interface{}{42}, "42   |"},            // Test minus flag overrides zero flag
+		{"%.1v", []interface{}{true}, "true"},              // Test precision does not truncate bools
+		{"%06v|", []interface{}{true}, "00true|"},          // Test zero padded bool
+		{"%.2v|", []interface{}{nil}, "<nil>|"},            // Test precision does not truncate nil
+		{"%.1v", []interface{}{"abc"}, "a"},                // Test precision truncates strings
+		{"%06v|", []interface{}{"ab"}, "0000ab|"},          // Test zero padded string
+		{"%08x", []interface{}{255}, "0x0000ff"},           // Test zero padding counts the prefix
+		{"%#08x", []interface{}{255}, "0x0000ff"},          // Test sharp flag is implied for integers
+		{"%.4x", []interface{}{255}, "0x00ff"},             // Test hexadecimal precision as minimum digits
+		{"%-8x|", []interface{}{255}, "0xff    |"},         // Test left-aligned hexadecimal
+		{"%+08b", []interface{}{5}, "+0b00101"},            // Test zero padding counts the sign and prefix
+		{"%10x", []interface{}{255}, "      0xff"},         // Test padded hexadecimal with prefix
+		{"%-8b|", []interface{}{5}, "0b101   |"},           // Test left-aligned binary
+		{"%6o", []interface{}{8}, "  0o10"},                // Test padded octal
+		{"%8.2f", []interface{}{3.14159}, "    3.14"},      // Test padded float
+		{"%-8.2f|", []interface{}{3.14159}, "3.14    |"},   // Test left-aligned float
+		{"%08.2f", []interface{}{-3.14159}, "-0003.14"},    // Test zero padded negative float
+		{"%+.2f", []interface{}{3.14159}, "+3.14"},         // Test forced plus sign on float
+		{"%8s|", []interface{}{"héllo"}, "   héllo|"},      // Test width counted in runes
+		{"%-6s|", []interface{}{"ab"}, "ab    |"},          // Test left-aligned string
+		{"%05s", []interface{}{"ab"}, "000ab"},             // Test zero padded string
+		{"%.2s", []interface{}{"héllo"}, "hé"},             // Test string precision truncates runes
+		{"%6v|", []interface{}{true}, "  true|"},           // Test padded %v bool
+		{"%+v", []interface{}{-1}, "-1"},                   // Test plus flag with negative %v int
+		{"%-4v|", []interface{}{7}, "7   |"},               // Test left-aligned %v int
+		{"%5%", []interface{}{}, "%"},                      // Test flags ignored for escaped percent
+		{"%-+ 0#12d|", []interface{}{9}, "+9          |"},  // Test all flags combined
+		{"%3d|%-3d|", []interface{}{1, 2}, "  1|2  |"},     // Test consecutive padded fields
+		{"%s:%4d", []interface{}{"temp", 21}, "temp:  21"}, // Test telemetry-style column
+		{"%12.3f|", []interface{}{-1.5}, "      -1.500|"},  // Test wide negative float
+	}
+
+	for _, testCase := range testCases {
+		got, err := Sprintf(testCase.format, testCase.arguments...)
+		if err != nil {
+			t.Errorf("Sprintf(%q, %v) unexpected error: %v", testCase.format, testCase.arguments, err)
+			continue
+		}
+		if got != testCase.want {
+			t.Errorf("Sprintf(%q, %v) = %q, want %q", testCase.format, testCase.arguments, got, testCase.want)
+		}
+	}
+}
+
+func TestSprintIntegerKinds(t *testing.T) {
+	testCases := []struct {
+		arguments []interface{}
+		want      string
+	}{
+		{[]interface{}{int8(-128)}, "-128"},                             // Test minimum int8
+		{[]interface{}{int16(math.MaxInt16)}, "32767"},                  // Test maximum int16
+		{[]interface{}{int32(-7)}, "-7"},                                // Test negative int32
+		{[]interface{}{int64(math.MinInt64)}, "-9223372036854775808"},   // Test minimum int64
+		{[]interface{}{uint(7)}, "7"},                                   // Test uint
+		{[]interface{}{uint8(255)}, "255"},                              // Test maximum uint8
+		{[]interface{}{uint16(65535)}, "65535"},                         // Test maximum uint16
+		{[]interface{}{uint32(math.MaxUint32)}, "4294967295"},           // Test maximum uint32
+		{[]interface{}{uint64(math.MaxUint64)}, "18446744073709551615"}, // Test maximum uint64
+		{[]interface{}{uintptr(4096)}, "4096"},                          // Test uintptr
+		{[]interface{}{[]uint8{1, 2, 3}}, "[1 2 3]"},                    // Test slice of uint8
 	}
 
+	for _, testCase := range testCases {
+		got := Sprint(testCase.arguments...)
+		if got != testCase.want {
+			t.Errorf("Sprint(%v) = %q, want %q", testCase.arguments, got, testCase.want)
+		}
+	}
+}
+
+func TestSprintfIntegerKinds(t *testing.T) {
 	testCases := []struct {
 		format    string
 		arguments []interface{}
 		want      string
 		shouldErr bool
 	}{
-		{"Hello, %s!", []interface{}{"world"}, "Hello, world!", false},                                          // Test formatting string
-		{"Value: %d", []interface{}{42}, "Value: 42", false},                                                    // Test formatting integer
-		{"Hex: %x", []interface{}{255}, "Hex: 0xff", false},                                                     // Test formatting hexadecimal
-		{"Binary: %b", []interface{}{7}, "Binary: 0b111", false},                                                // Test formatting binary
-		{"Octal: %o", []interface{}{64}, "Octal: 0o100", false},                                                 // Test formatting octal
-		{"Float: %.2f", []interface{}{3.14159}, "Float: 3.14", false},                                           // Test formatting float with precision
-		{"Float: %.5f", []interface{}{3.14159}, "Float: 3.14159", false},                                        // Test formatting float with higher precision
-		{"Bool: %v", []interface{}{true}, "Bool: true", false},                                                  // Test formatting boolean true
-		{"Bool: %v", []interface{}{false}, "Bool: false", false},                                                // Test formatting boolean false
-		{"Multiple: %d, %s, %v", []interface{}{42, "test", true}, "Multiple: 42, test, true", false},            // Test multiple format specifiers
-		{"Precision: %.0f", []interface{}{123.456}, "Precision: 123", false},                                    // Test float with precision 0
-		{"Invalid: %q", []interface{}{42}, "", true},                                                            // Test unsupported format specifier
-		{"Missing arg: %d %d", []interface{}{42}, "", true},                                                     // Test missing argument
-		{"Edge case: %d", []interface{}{math.MaxInt64}, "Edge case: 9223372036854775807", false},                // Test edge case for large integer
-		{"Negative: %d", []interface{}{-123}, "Negative: -123", false},                                          // Test negative integer
-		{"Escape: %%", []interface{}{}, "Escape: %", false},                                                     // Test escape percentage sign
-		{"Slice: %v", []interface{}{[]int{1, 2, 3}}, "Slice: [1 2 3]", false},                                   // Test formatting slice
-		{"Map: %v", []interface{}{map[string]int{"key": 1}}, "Map: {key:1}", false},                             // Test formatting map
-		{"Struct: %v", []interface{}{ExampleStruct{"example", 123}}, "Struct: {Name:example Value:123}", false}, // Test formatting struct
+		{"%d", []interface{}{uint8(200)}, "200", false},                              // Test uint8 decimal
+		{"%x", []interface{}{uint16(0xbeef)}, "0xbeef", false},                       // Test uint16 hexadecimal
+		{"%010x", []interface{}{uint32(0xff)}, "0x000000ff", false},                  // Test zero padded uint32
+		{"%b", []interface{}{uint8(5)}, "0b101", false},                              // Test uint8 binary
+		{"%o", []interface{}{int16(-8)}, "-0o10", false},                             // Test negative int16 octal
+		{"%d", []interface{}{int64(math.MinInt64)}, "-9223372036854775808", false},   // Test minimum int64
+		{"%d", []interface{}{uint64(math.MaxUint64)}, "18446744073709551615", false}, // Test maximum uint64
+		{"%x", []interface{}{uint64(math.MaxUint64)}, "0xffffffffffffffff", false},   // Test maximum uint64 hexadecimal
+		{"%v", []interface{}{uint32(123456)}, "123456", false},                       // Test uint32 with %v
+		{"%+d", []interface{}{uintptr(1)}, "+1", false},                              // Test uintptr with plus flag
+		{"%d", []interface{}{"text"}, "", true},                                      // Test non-integer argument
+		{"%x", []interface{}{3.5}, "", true},                                         // Test float for integer verb
 	}
 
 	for _, testCase := range testCases {
@@ -104,3 +236,155 @@ func TestSprintf(t *testing.T) {
 		}
 	}
 }
+
+func TestSprintfFloat32AndComplex(t *testing.T) {
+	testCases := []struct {
+		format    string
+		arguments []interface{}
+		want      string
+		shouldErr bool
+	}{
+		{"%.2f", []interface{}{float32(3.14159)}, "3.14", false},              // Test float32 with precision
+		{"%v", []interface{}{float32(0.1)}, "0.1", false},                     // Test float32 without widening noise
+		{"%8.1f", []interface{}{float32(-2.26)}, "    -2.3", false},           // Test padded float32
+		{"%.2f", []interface{}{complex(1, -2)}, "(1.00-2.00i)", false},        // Test complex128 with precision
+		{"%.1f", []interface{}{complex64(1.5 + 0.5i)}, "(1.5+0.5i)", false},   // Test complex64 with precision
+		{"%8.2f", []interface{}{complex(1, 2)}, "(    1.00   +2.00i)", false}, // Test width applied to each part
+		{"%v", []interface{}{complex64(3i)}, "(0+3i)", false},                 // Test complex64 with %v
+		{"%f", []interface{}{"text"}, "", true},                               // Test non-float argument
+	}
+
+	for _, testCase := range testCases {
+		got, err := Sprintf(testCase.format, testCase.arguments...)
+		if (err != nil) != testCase.shouldErr {
+			t.Errorf("Sprintf(%q, %v) error = %v, wantErr %v", testCase.format, testCase.arguments, err, testCase.shouldErr)
+			continue
+		}
+		if got != testCase.want {
+			t.Errorf("Sprintf(%q, %v) = %q, want %q", testCase.format, testCase.arguments, got, testCase.want)
+		}
+	}
+}
+
+func TestSprintfHex(t *testing.T) {
+	testCases := []struct {
+		format    string
+		arguments []interface{}
+		want      string
+		shouldErr bool
+	}{
+		{"%X", []interface{}{255}, "0XFF", false},                        // Test upper-case hexadecimal integer
+		{"%08X", []interface{}{uint32(0xbeef)}, "0X00BEEF", false},       // Test zero padded upper-case hexadecimal
+		{"%x", []interface{}{"hi"}, "6869", false},                       // Test hex encoded string
+		{"%X", []interface{}{[]byte{0x01, 0xab, 0xff}}, "01ABFF", false}, // Test upper-case hex encoded bytes
+		{"% x", []interface{}{[]byte{1, 2, 171}}, "01 02 ab", false},     // Test space separated bytes
+		{"%#x", []interface{}{"hi"}, "0x6869", false},                    // Test prefixed string
+		{"%# X", []interface{}{[]byte{1, 171}}, "0X01 0XAB", false},      // Test prefixed separated bytes
+		{"%.2x", []interface{}{[]byte{1, 2, 3}}, "0102", false},          // Test precision limits bytes
+		{"%8x|", []interface{}{[]byte{0xde, 0xad}}, "    dead|", false},  // Test padded hex bytes
+		{"%-8x|", []interface{}{"hi"}, "6869    |", false},               // Test left-aligned hex string
+		{"%x", []interface{}{[]byte{}}, "", false},                       // Test empty byte slice
+		{"%d", []interface{}{[]byte{1}}, "", true},                       // Test byte slice for %d
+		{"%o", []interface{}{"hi"}, "", true},                            // Test string for %o
+	}
+
+	for _, testCase := range testCases {
+		got, err := Sprintf(testCase.format, testCase.arguments...)
+		if (err != nil) != testCase.shouldErr {
+			t.Errorf("Sprintf(%q, %v) error = %v, wantErr %v", testCase.format, testCase.arguments, err, testCase.shouldErr)
+			continue
+		}
+		if got != testCase.want {
+			t.Errorf("Sprintf(%q, %v) = %q, want %q", testCase.format, testCase.arguments, got, testCase.want)
+		}
+	}
+}
+
+func TestSprintfLenient(t *testing.T) {
+	testCases := []struct {
+		format    string
+		arguments []interface{}
+		want      string
+	}{
+		{"Value: %d", []interface{}{42}, "Value: 42"},                                      // Test valid directives are unchanged
+		{"%d|%s", []interface{}{"hi", "ok"}, "%!d(string=hi)|ok"},                          // Test wrong type marker
+		{"%s", []interface{}{nil}, "%!s(<nil>)"},                                           // Test nil argument marker
+		{"%z and %v", []interface{}{2, true}, "%!z(int=2) and true"},                       // Test bad verb marker
+		{"a%éb", []interface{}{1}, "a%!é(int=1)b"},                                         // Test multibyte bad verb marker
+		{"%d %5s", []interface{}{1}, "1 %!s(MISSING)"},                                     // Test missing argument marker
+		{"end %-", []interface{}{}, "end %!(NOVERB)"},                                      // Test missing verb marker
+		{"%d", []interface{}{1, "a", 3.5, nil}, "1%!(EXTRA string=a, float64=3.5, <nil>)"}, // Test extra arguments marker
+		{"%w", []interface{}{"x"}, "%!w(string=x)"},                                        // Test %w outside Errorf
+		{"%.1f%%", []interface{}{[]int{1}}, "%!f([]int=[1])%"},                             // Test composite argument marker
+		{"%[d %d", []interface{}{"x"}, "%!d(BADINDEX) %!d(string=x)"},                      // Test unclosed index marker
+		{"%[[%d", []interface{}{"x"}, "%d"},                                                // Test repeated unclosed index before a percent
+		{"%[x].2f", []interface{}{1.5}, "%!f(BADINDEX)"},                                   // Test non-numeric index marker
+		{"%[]d", []interface{}{1}, "%!d(BADINDEX)"},                                        // Test empty index marker
+		{"%[x][1]d", []interface{}{1}, "%!d(BADINDEX)"},                                    // Test malformed index before a valid one
+		{"%[[[d", []interface{}{1}, "%![(BADINDEX)d"},                                      // Test unclosed indexes before the verb
+	}
+
+	for _, testCase := range testCases {
+		got := SprintfLenient(testCase.format, testCase.arguments...)
+		if got != testCase.want {
+			t.Errorf("SprintfLenient(%q, %v) = %q, want %q", testCase.format, testCase.arguments, got, testCase.want)
+		}
+	}
+}
+
+func TestSprintfArgumentIndexes(t *testing.T) {
+	testCases := []struct {
+		format    string
+		arguments []interface{}
+		want      string
+	}{
+		{"%[2]d %[1]d", []interface{}{1, 2}, "2 1"},                    // Test reordered arguments
+		{"%[1]d %[1]x %#[1]o", []interface{}{64}, "64 0x40 0o100"},     // Test reused argument
+		{"%d %d %#[1]x %#x", []interface{}{16, 17}, "16 17 0x10 0x11"}, // Test indexing resumes from the index
+		{"%[3]*.[2]*[1]f", []interface{}{12.0, 2, 6}, " 12.00"},        // Test indexed width and precision
+		{"%[2]*[1]d|", []interface{}{7, 4}, "   7|"},                   // Test indexed width then index
+		{"%*d|", []interface{}{5, 3}, "    3|"},                        // Test width from argument
+		{"%*d|", []interface{}{-5, 3}, "3    |"},                       // Test negative width pads right
+		{"%0*d|", []interface{}{-5, 3}, "3    |"},                      // Test negative width cancels zero padding
+		{"%.*f", []interface{}{1, 3.14159}, "3.1"},                     // Test precision from argument
+		{"%-*.*s|", []interface{}{6, 2, "abc"}, "ab    |"},             // Test width and precision from arguments
+		{"%*d", []interface{}{uint8(3), 1}, "  1"},                     // Test width from another integer type
+		{"%[2]d", []interface{}{1, 2}, "2"},                            // Test unused arguments are allowed with indexes
+		{"%*%", []interface{}{5}, "%"},                                 // Test literal percent consumes its width
+	}
+
+	for _, testCase := range testCases {
+		got, err := Sprintf(testCase.format, testCase.arguments...)
+		if err != nil {
+			t.Errorf("Sprintf(%q, %v) returned error: %v", testCase.format, testCase.arguments, err)
+			continue
+		}
+		if got != testCase.want {
+			t.Errorf("Sprintf(%q, %v) = %q, want %q", testCase.format, testCase.arguments, got, testCase.want)
+		}
+	}
+
+	lenientCases := []struct {
+		format    string
+		arguments []interface{}
+		want      string
+	}{
+		{"%[5]d", []interface{}{1, 2}, "%!d(BADINDEX)"},        // Test index out of range
+		{"%[0]d", []interface{}{1}, "%!d(BADINDEX)"},           // Test zero index
+		{"%[x]d", []interface{}{1}, "%!d(BADINDEX)"},           // Test malformed index
+		{"%[1]2d", []interface{}{1}, "%!d(BADINDEX)"},          // Test index before a width
+		{"%[3]d %d", []interface{}{1, 2, 3}, "3 %!d(MISSING)"}, // Test running past the last argument
+		{"%*d", []interface{}{"x", 3}, "%!(BADWIDTH)3"},        // Test non-integer width
+		{"%*d", []interface{}{10000000, 3}, "%!(BADWIDTH)3"},   // Test oversized width
+		{"%.*d", []interface{}{-1, 3}, "%!(BADPREC)3"},         // Test negative precision
+		{"%*d", []interface{}{}, "%!(BADWIDTH)%!d(MISSING)"},   // Test missing width
+		{"%[2]", []interface{}{1}, "%!(NOVERB)"},               // Test index without a verb
+	}
+
+	for _, testCase := range lenientCases {
+		got := SprintfLenient(testCase.format, testCase.arguments...)
+		if got != testCase.want {
+			t.Errorf("SprintfLenient(%q, %v) = %q, want %q", testCase.format, testCase.arguments, got, testCase.want)
+		}
+	}
+}